@@ -0,0 +1,238 @@
+package bezierscore
+
+import (
+	"math"
+	"math/big"
+)
+
+// ScoreBig returns the computed Bezier score for position using math/big.Float arithmetic at prec bits of precision,
+// avoiding the rounding error float64 accumulates while evaluating the curve.
+//
+// The interpolation is computed exactly when the exponent is a whole number. Fractional exponents and easing
+// functions are evaluated in float64, since math/big has no power function, so only the curve itself benefits from
+// the extra precision in that case.
+//
+// position must be at least 1, and at most the participantCount, just like Score. prec must be at least 1.
+func (s *System) ScoreBig(position uint, prec uint) (*big.Float, bool) {
+	if position == 0 || position > s.participantCount || prec == 0 {
+		return nil, false
+	}
+
+	numerator := new(big.Float).SetPrec(prec).SetUint64(uint64(position - 1))
+	denominator := new(big.Float).SetPrec(prec).SetUint64(uint64(s.participantCount - 1))
+	return s.scoreBigAt(numerator, denominator, prec), true
+}
+
+// scoreBigAt is the math/big equivalent of scoreAt.
+func (s *System) scoreBigAt(numerator, denominator *big.Float, prec uint) *big.Float {
+	newFloat := func(value float64) *big.Float {
+		return new(big.Float).SetPrec(prec).SetFloat64(value)
+	}
+
+	// only whole positions can be overridden.
+	override, overridden := 0.0, false
+	if s.overrides != nil {
+		offset := newFloat(0).Mul(numerator, newFloat(float64(s.participantCount-1)))
+		if whole, accuracy := offset.Quo(offset, denominator).Uint64(); accuracy == big.Exact {
+			override, overridden = s.overrides[uint(whole)+1]
+		}
+	}
+
+	floored := false
+	if s.floorPosition > 0 {
+		offset := newFloat(0).Mul(numerator, newFloat(float64(s.participantCount-1)))
+		floor := newFloat(0).Mul(denominator, newFloat(float64(s.floorPosition-1)))
+		floored = offset.Cmp(floor) > 0
+	}
+
+	if s.reversed {
+		numerator = newFloat(0).Sub(denominator, numerator)
+	}
+
+	var score *big.Float
+	switch {
+	case overridden:
+		score = newFloat(override)
+	case floored:
+		score = newFloat(s.floorScore)
+	case s.decayRate > 0:
+		steps := newFloat(0).Mul(numerator, newFloat(float64(s.participantCount-1)))
+		stepsFloat, _ := steps.Quo(steps, denominator).Float64()
+		score = newFloat(s.decay(stepsFloat))
+	case s.piecewise != nil:
+		score = s.segmentBig(numerator, denominator, prec)
+	default:
+		alpha := newFloat(0).Quo(numerator, denominator)
+		score = s.curveBig(alpha, prec)
+	}
+
+	// round score half away from zero to the nearest multiple of step, like math.Round.
+	round := func(step float64) {
+		steps := newFloat(0).Quo(score, newFloat(step))
+		if steps.Sign() >= 0 {
+			steps.Add(steps, newFloat(0.5))
+		} else {
+			steps.Sub(steps, newFloat(0.5))
+		}
+
+		whole, _ := steps.Int(nil)
+		score.SetInt(whole)
+		score.Mul(score, newFloat(step))
+	}
+
+	if s.quantum > 0 {
+		round(s.quantum)
+	}
+
+	if s.integerScores {
+		round(1)
+	}
+
+	if scoreCap := newFloat(s.effectiveCap()); s.scoreCap > 0 && score.Cmp(scoreCap) > 0 {
+		score = scoreCap
+	}
+
+	return score
+}
+
+// segmentBig is the math/big equivalent of segment, scoring the fraction with the System covering it. numerator has
+// already been mirrored if the System is reversed.
+func (s *System) segmentBig(numerator, denominator *big.Float, prec uint) *big.Float {
+	newFloat := func(value float64) *big.Float {
+		return new(big.Float).SetPrec(prec).SetFloat64(value)
+	}
+
+	total := newFloat(float64(s.participantCount - 1))
+	offset := newFloat(0).Mul(numerator, total)
+	offset.Quo(offset, denominator)
+
+	split := newFloat(float64(s.piecewise.split - 1))
+	if offset.Cmp(split) <= 0 {
+		return s.piecewise.top.scoreBigAt(offset, split, prec)
+	}
+
+	return s.piecewise.bottom.scoreBigAt(offset.Sub(offset, split), total.Sub(total, split), prec)
+}
+
+// curveBig is the math/big equivalent of curve(warp(linear)).
+func (s *System) curveBig(linear *big.Float, prec uint) *big.Float {
+	newFloat := func(value float64) *big.Float {
+		return new(big.Float).SetPrec(prec).SetFloat64(value)
+	}
+
+	upper, lower := newFloat(s.upperBound), newFloat(s.lowerBound)
+
+	middle := newFloat(0).Add(upper, lower)
+	middle.Quo(middle, newFloat(2))
+
+	// control = ((1 - coeff) * middle) + (coeff * towards), mirroring control and control2.
+	blend := func(coeff float64, towards *big.Float) *big.Float {
+		blended := newFloat(1)
+		blended.Sub(blended, newFloat(coeff))
+		blended.Mul(blended, middle)
+		return blended.Add(blended, newFloat(0).Mul(newFloat(coeff), towards))
+	}
+
+	control := blend(s.controlCoefficient, upper)
+	if s.explicitControl || s.roundControl {
+		control = newFloat(s.controlPoint)
+	}
+
+	var alpha *big.Float
+	if exponent := s.exponent; s.ease == nil && exponent == math.Trunc(exponent) {
+		// front-loading raises 1-linear rather than linear, and flips the result back.
+		base := newFloat(0).Set(linear)
+		if s.exponentMode == ExpFrontLoad {
+			base.Sub(newFloat(1), linear)
+		}
+
+		alpha = powBig(base, exponent, prec)
+
+		if s.exponentMode == ExpFrontLoad {
+			alpha.Sub(newFloat(1), alpha)
+		}
+	} else {
+		value, _ := linear.Float64()
+		alpha = newFloat(s.warp(value))
+	}
+
+	inverse := newFloat(1)
+	inverse.Sub(inverse, alpha)
+
+	// sum the bernstein terms of the quadratic or cubic curve.
+	var points []*big.Float
+	if s.cubic {
+		control2 := blend(s.controlCoefficient2, lower)
+		if s.roundControl {
+			control2 = newFloat(s.controlPoint2)
+		}
+
+		points = []*big.Float{upper, control, control2, lower}
+	} else {
+		points = []*big.Float{upper, control, lower}
+	}
+
+	degree := len(points) - 1
+	score := newFloat(0)
+	for idx, point := range points {
+		term := newFloat(float64(binomial(degree, idx)))
+		term.Mul(term, point)
+		for range degree - idx {
+			term.Mul(term, inverse)
+		}
+
+		for range idx {
+			term.Mul(term, alpha)
+		}
+
+		score.Add(score, term)
+	}
+
+	return score
+}
+
+// powBig raises base to the power of exponent, which must be a whole number, by repeated squaring. This takes
+// O(log exponent) multiplications, so even the largest float64 exponents are computed quickly.
+func powBig(base *big.Float, exponent float64, prec uint) *big.Float {
+	power, _ := new(big.Float).SetFloat64(exponent).Int(nil)
+
+	result := new(big.Float).SetPrec(prec).SetInt64(1)
+	square := new(big.Float).SetPrec(prec).Set(base)
+	for bit := range power.BitLen() {
+		if power.Bit(bit) == 1 {
+			result.Mul(result, square)
+		}
+
+		square.Mul(square, square)
+	}
+
+	return result
+}
+
+// binomial returns n choose k for the small n used by bezier curves.
+func binomial(n, k int) int {
+	result := 1
+	for idx := 1; idx <= k; idx++ {
+		result = result * (n - k + idx) / idx
+	}
+
+	return result
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
@@ -0,0 +1,86 @@
+package bezierscore
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestScoreBigMatchesScore(t *testing.T) {
+	for _, exp := range []float64{1, 1.33, 3} {
+		system := newSystem(t, 500, 1000, 100000, 0.5, exp)
+		for _, position := range []uint{1, 2, 100, 250, 499, 500} {
+			score, ok := system.ScoreBig(position, 128)
+			if !ok {
+				t.Fatalf("ScoreBig(%d, 128) is not ok", position)
+			}
+
+			got, _ := score.Float64()
+			if want, _ := system.Score(position); math.Abs(got-want) > 1e-9*want {
+				t.Errorf("exp %g: ScoreBig(%d) = %g, want about %g", exp, position, got, want)
+			}
+		}
+	}
+}
+
+func TestScoreBigPrecision(t *testing.T) {
+	// halfway down a linear curve from 2^54 to 3 scores exactly 2^53 + 1.5, which float64 cannot represent.
+	system := newSystem(t, 3, 3, math.Exp2(54), 0, 1)
+
+	want := new(big.Float).SetPrec(128).SetFloat64(math.Exp2(53))
+	want.Add(want, big.NewFloat(1.5))
+
+	got, _ := system.ScoreBig(2, 128)
+	if got.Cmp(want) != 0 {
+		t.Errorf("ScoreBig(2, 128) = %s, want %s", got.Text('f', 2), want.Text('f', 2))
+	}
+
+	if score, _ := system.Score(2); new(big.Float).SetFloat64(score).Cmp(want) == 0 {
+		t.Errorf("Score(2) = %f, want float64 to round it", score)
+	}
+}
+
+func TestScoreBigLargeExponent(t *testing.T) {
+	for _, exp := range []float64{1e8, 1e15, 1e300} {
+		system := newSystem(t, 500, 1000, 100000, 0.5, exp)
+
+		start := time.Now()
+		score, ok := system.ScoreBig(250, 128)
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("exp %g: ScoreBig took %v, want it to scale with log(exp)", exp, elapsed)
+		}
+
+		got, _ := score.Float64()
+		if want, _ := system.Score(250); !ok || math.Abs(got-want) > 1e-9*want {
+			t.Errorf("exp %g: ScoreBig(250) = %g, %t, want about %g", exp, got, ok, want)
+		}
+	}
+}
+
+func TestScoreBigInvalid(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for _, args := range [][2]uint{{0, 64}, {501, 64}, {1, 0}} {
+		if score, ok := system.ScoreBig(args[0], args[1]); ok {
+			t.Errorf("ScoreBig(%d, %d) = %v, true, want false", args[0], args[1], score)
+		}
+	}
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
@@ -0,0 +1,367 @@
+package bezierscore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// encodable returns an error if the System has state that none of the encodings can represent: an easing function
+// from NewWithEasing, or the Systems of a piecewise System from NewPiecewise.
+func (s *System) encodable() error {
+	if s.ease != nil {
+		return errors.New("bezierscore: Systems with a custom easing function cannot be encoded")
+	}
+
+	if s.piecewise != nil {
+		return errors.New("bezierscore: piecewise Systems cannot be encoded")
+	}
+
+	return nil
+}
+
+// binaryLen is the length of the MarshalBinary encoding: a uint32 participant count followed by four float64s.
+const binaryLen = 4 + (4 * 8)
+
+type systemJSON struct {
+	ParticipantCount uint             `json:"participantCount"`
+	ScoreMin         float64          `json:"scoreMin"`
+	ScoreMax         float64          `json:"scoreMax"`
+	Coefficient      float64          `json:"coeff"`
+	Coefficient2     *float64         `json:"coeff2,omitempty"`
+	Control          *float64         `json:"control,omitempty"`
+	Exponent         float64          `json:"exp"`
+	ExponentMode     int              `json:"expMode,omitempty"`
+	Reversed         bool             `json:"reversed,omitempty"`
+	Quantum          float64          `json:"quantum,omitempty"`
+	Unbounded        bool             `json:"unbounded,omitempty"`
+	RoundControl     bool             `json:"roundControl,omitempty"`
+	IntegerScores    bool             `json:"integerScores,omitempty"`
+	FloorPosition    uint             `json:"floorPosition,omitempty"`
+	FloorScore       float64          `json:"floorScore,omitempty"`
+	ScoreCap         float64          `json:"scoreCap,omitempty"`
+	DecayRate        float64          `json:"decayRate,omitempty"`
+	Overrides        map[uint]float64 `json:"overrides,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, encoding the parameters the System was constructed with and the options
+// applied to it. coeff2 is only present for cubic Systems, and control is only present for Systems constructed with
+// NewWithControl. Systems constructed with NewWithEasing or NewPiecewise cannot be encoded.
+func (s *System) MarshalJSON() ([]byte, error) {
+	if err := s.encodable(); err != nil {
+		return nil, err
+	}
+
+	encoded := systemJSON{
+		ParticipantCount: s.participantCount,
+		ScoreMin:         s.lowerBound,
+		ScoreMax:         s.upperBound,
+		Coefficient:      s.controlCoefficient,
+		Exponent:         s.exponent,
+		ExponentMode:     int(s.exponentMode),
+		Reversed:         s.reversed,
+		Quantum:          s.quantum,
+		Unbounded:        s.unbounded,
+		RoundControl:     s.roundControl,
+		IntegerScores:    s.integerScores,
+		FloorPosition:    s.floorPosition,
+		FloorScore:       s.floorScore,
+		ScoreCap:         s.scoreCap,
+		DecayRate:        s.decayRate,
+		Overrides:        s.overrides,
+	}
+
+	if s.cubic {
+		encoded.Coefficient2 = &s.controlCoefficient2
+	}
+
+	if s.explicitControl {
+		encoded.Control = &s.controlPoint
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The decoded parameters are validated with Validate, and any violation is
+// returned wrapping the corresponding error.
+func (s *System) UnmarshalJSON(data []byte) error {
+	var decoded systemJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	system := &System{
+		participantCount:   decoded.ParticipantCount,
+		upperBound:         decoded.ScoreMax,
+		lowerBound:         decoded.ScoreMin,
+		controlCoefficient: decoded.Coefficient,
+		exponent:           decoded.Exponent,
+		exponentMode:       ExponentMode(decoded.ExponentMode),
+		reversed:           decoded.Reversed,
+		quantum:            decoded.Quantum,
+		unbounded:          decoded.Unbounded,
+		roundControl:       decoded.RoundControl,
+		integerScores:      decoded.IntegerScores,
+		floorPosition:      decoded.FloorPosition,
+		floorScore:         decoded.FloorScore,
+		scoreCap:           decoded.ScoreCap,
+		decayRate:          decoded.DecayRate,
+		overrides:          decoded.Overrides,
+	}
+
+	if decoded.Coefficient2 != nil {
+		system.cubic = true
+		system.controlCoefficient2 = *decoded.Coefficient2
+	}
+
+	if decoded.Control != nil {
+		system.explicitControl = true
+		system.controlPoint = *decoded.Control
+	}
+
+	if err := system.Validate(); err != nil {
+		return fmt.Errorf("bezierscore: invalid System: %w", err)
+	}
+
+	system.prepare()
+	*s = *system
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is little-endian: a uint32 participantCount followed
+// by the float64 scoreMin, scoreMax, coeff and exp.
+//
+// There is no room in the layout for anything else, so Systems constructed with NewCubic, NewWithControl,
+// NewWithEasing, NewUnbounded or NewPiecewise, and Systems with options like WithReversed or WithOverride, cannot be
+// binary encoded. An error is returned for them rather than silently dropping what they add; MarshalJSON and
+// MarshalText encode everything but easing functions and piecewise Systems. The logger set by WithLogger is never
+// encoded.
+func (s *System) MarshalBinary() ([]byte, error) {
+	if err := s.encodable(); err != nil {
+		return nil, err
+	}
+
+	if s.cubic {
+		return nil, errors.New("bezierscore: cubic Systems cannot be binary encoded")
+	}
+
+	if s.explicitControl {
+		return nil, errors.New("bezierscore: Systems with an explicit control point cannot be binary encoded")
+	}
+
+	if s.unbounded {
+		return nil, errors.New("bezierscore: unbounded Systems cannot be binary encoded")
+	}
+
+	if s.reversed || s.quantum != 0 || s.roundControl || s.floorPosition > 0 || s.scoreCap != 0 || s.decayRate != 0 ||
+		s.exponentMode != ExpBackLoad || s.integerScores || len(s.overrides) > 0 {
+		return nil, errors.New("bezierscore: Systems with options cannot be binary encoded")
+	}
+
+	// every System is validated on construction, so participantCount is at most MaxParticipantCount, which fits in a
+	// uint32.
+	data := make([]byte, 0, binaryLen)
+	data = binary.LittleEndian.AppendUint32(data, uint32(s.participantCount))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.lowerBound))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.upperBound))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.controlCoefficient))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.exponent))
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the layout produced by MarshalBinary. The decoded
+// parameters are validated the same way New validates them.
+func (s *System) UnmarshalBinary(data []byte) error {
+	if len(data) != binaryLen {
+		return fmt.Errorf("bezierscore: binary System must be %d bytes, got %d", binaryLen, len(data))
+	}
+
+	participantCount := binary.LittleEndian.Uint32(data[0:4])
+	scoreMin := math.Float64frombits(binary.LittleEndian.Uint64(data[4:12]))
+	scoreMax := math.Float64frombits(binary.LittleEndian.Uint64(data[12:20]))
+	coeff := math.Float64frombits(binary.LittleEndian.Uint64(data[20:28]))
+	exp := math.Float64frombits(binary.LittleEndian.Uint64(data[28:36]))
+
+	system, err := New(uint(participantCount), scoreMin, scoreMax, coeff, exp)
+	if err != nil {
+		return fmt.Errorf("bezierscore: invalid System: %w", err)
+	}
+
+	*s = *system
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the same layout as MarshalBinary, so the same Systems cannot be encoded.
+func (s *System) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the same layout as UnmarshalBinary, validating the decoded parameters the
+// same way New validates them.
+func (s *System) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// Fingerprint returns a 64-bit FNV-1a hash of the System's parameters and options, for detecting when a scoring
+// configuration changes between deploys. The parameters are hashed in a fixed order, so the fingerprint is stable
+// across runs and platforms, and changing any parameter or option changes it.
+//
+// Systems constructed with NewWithEasing hash their easing function's output at every position, since the function
+// itself cannot be hashed. Systems constructed with NewPiecewise hash both of their Systems. The logger set by
+// WithLogger is not hashed.
+func (s *System) Fingerprint() uint64 {
+	digest := fnv.New64a()
+	s.fingerprint(digest)
+	return digest.Sum64()
+}
+
+func (s *System) fingerprint(digest hash.Hash64) {
+	var flags byte
+	for bit, set := range []bool{s.reversed, s.roundControl, s.unbounded, s.cubic, s.explicitControl, s.integerScores} {
+		if set {
+			flags |= 1 << bit
+		}
+	}
+
+	data := binary.LittleEndian.AppendUint64(nil, uint64(s.participantCount))
+	data = binary.LittleEndian.AppendUint64(data, uint64(s.floorPosition))
+	data = binary.LittleEndian.AppendUint64(data, uint64(s.exponentMode))
+	data = append(data, flags)
+	for _, value := range []float64{
+		s.lowerBound,
+		s.upperBound,
+		s.controlCoefficient,
+		s.controlCoefficient2,
+		s.controlPoint,
+		s.exponent,
+		s.quantum,
+		s.floorScore,
+		s.scoreCap,
+		s.decayRate,
+	} {
+		// adding 0 turns -0 into 0, so that the two hash the same.
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(value+0))
+	}
+
+	digest.Write(data)
+
+	for _, position := range slices.Sorted(maps.Keys(s.overrides)) {
+		data = binary.LittleEndian.AppendUint64(data[:0], uint64(position))
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(s.overrides[position]+0))
+		digest.Write(data)
+	}
+
+	if s.ease != nil {
+		denominator := float64(s.participantCount - 1)
+		for numerator := range s.participantCount {
+			alpha := s.warp(float64(numerator) / denominator)
+			data = binary.LittleEndian.AppendUint64(data[:0], math.Float64bits(alpha+0))
+			digest.Write(data)
+		}
+	}
+
+	if s.piecewise != nil {
+		digest.Write(binary.LittleEndian.AppendUint64(nil, uint64(s.piecewise.split)))
+		s.piecewise.top.fingerprint(digest)
+		s.piecewise.bottom.fingerprint(digest)
+	}
+}
+
+// ScoresBytes returns the score for every position, from first place to last place, encoded as consecutive
+// big-endian float64s, 8 bytes per position. This is a compact form of the leaderboard for binary network protocols,
+// decoded by DecodeScores.
+func (s *System) ScoresBytes() []byte {
+	data := make([]byte, 0, 8*s.participantCount)
+	for position := uint(1); position <= s.participantCount; position++ {
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(s.ScoreUnchecked(position)))
+	}
+
+	return data
+}
+
+// DecodeScores decodes scores encoded by ScoresBytes. len(data) must be a multiple of 8.
+func DecodeScores(data []byte) ([]float64, error) {
+	if len(data)%8 != 0 {
+		return nil, fmt.Errorf("bezierscore: encoded scores must be a multiple of 8 bytes, got %d", len(data))
+	}
+
+	scores := make([]float64, len(data)/8)
+	for idx := range scores {
+		scores[idx] = math.Float64frombits(binary.BigEndian.Uint64(data[idx*8:]))
+	}
+
+	return scores, nil
+}
+
+// WriteCSV writes the full leaderboard to w as CSV: a "position,score" header row, followed by one row for every
+// position from 1 to participantCount.
+func (s *System) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"position", "score"}); err != nil {
+		return err
+	}
+
+	for position := uint(1); position <= s.participantCount; position++ {
+		score, _ := s.Score(position)
+		record := []string{
+			strconv.FormatUint(uint64(position), 10),
+			strconv.FormatFloat(score, 'g', -1, 64),
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSONArray streams the score for every position to w as a JSON array, from first place to last place, without
+// building an intermediate slice.
+func (s *System) WriteJSONArray(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	writer.WriteByte('[')
+
+	var buf []byte
+	for position := uint(1); position <= s.participantCount; position++ {
+		if position > 1 {
+			writer.WriteByte(',')
+		}
+
+		score, _ := s.Score(position)
+		buf = strconv.AppendFloat(buf[:0], score, 'g', -1, 64)
+		writer.Write(buf)
+	}
+
+	writer.WriteByte(']')
+	return writer.Flush()
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
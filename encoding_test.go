@@ -0,0 +1,419 @@
+package bezierscore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	data, err := json.Marshal(system)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded System
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.Equal(system) {
+		t.Errorf("decoded %v from %s, want %v", &decoded, data, system)
+	}
+
+	for position := uint(1); position <= 500; position++ {
+		want, _ := system.Score(position)
+		if got, _ := decoded.Score(position); got != want {
+			t.Fatalf("decoded Score(%d) = %g, want %g", position, got, want)
+		}
+	}
+}
+
+func TestJSONFields(t *testing.T) {
+	data, err := json.Marshal(newSystem(t, 500, 1000, 100000, 0.5, 1.33))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"participantCount":500,"scoreMin":1000,"scoreMax":100000,"coeff":0.5,"exp":1.33}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+}
+
+func TestUnmarshalJSONRejectsInvalidCoefficient(t *testing.T) {
+	data := `{"participantCount":500,"scoreMin":1000,"scoreMax":100000,"coeff":1.5,"exp":1}`
+
+	var system System
+	err := json.Unmarshal([]byte(data), &system)
+	if !errors.Is(err, CoefficientOutOfRangeErr) {
+		t.Errorf("json.Unmarshal = %v, want CoefficientOutOfRangeErr", err)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	data, err := system.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data) != binaryLen {
+		t.Fatalf("len(MarshalBinary()) = %d, want %d", len(data), binaryLen)
+	}
+
+	if count := binary.LittleEndian.Uint32(data); count != 500 {
+		t.Errorf("encoded participantCount = %d, want 500", count)
+	}
+
+	var decoded System
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.Equal(system) {
+		t.Errorf("decoded %v, want %v", &decoded, system)
+	}
+}
+
+func TestUnmarshalBinaryRejectsWrongLength(t *testing.T) {
+	data, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, length := range []int{0, 4, len(data) - 1} {
+		var decoded System
+		if err := decoded.UnmarshalBinary(data[:length]); err == nil {
+			t.Errorf("UnmarshalBinary of %d bytes succeeded, want an error", length)
+		}
+	}
+
+	var decoded System
+	if err := decoded.UnmarshalBinary(append(data, 0)); err == nil {
+		t.Errorf("UnmarshalBinary of %d bytes succeeded, want an error", len(data)+1)
+	}
+}
+
+func TestUnmarshalBinaryRejectsInvalidParameters(t *testing.T) {
+	data, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// overwrite exp with NaN.
+	binary.LittleEndian.PutUint64(data[28:], math.Float64bits(math.NaN()))
+
+	var decoded System
+	if err := decoded.UnmarshalBinary(data); !errors.Is(err, NonFiniteParameterErr) {
+		t.Errorf("UnmarshalBinary = %v, want NonFiniteParameterErr", err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	var buf bytes.Buffer
+	if err := system.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 501 {
+		t.Fatalf("got %d rows, want a header and 500 rows", len(records))
+	}
+
+	if header := records[0]; len(header) != 2 || header[0] != "position" || header[1] != "score" {
+		t.Errorf("header = %q, want [position score]", header)
+	}
+
+	for idx, record := range records[1:] {
+		position, err := strconv.ParseUint(record[0], 10, 0)
+		if err != nil || position != uint64(idx)+1 {
+			t.Fatalf("row %d position = %q, want %d", idx+1, record[0], idx+1)
+		}
+
+		score, err := strconv.ParseFloat(record[1], 64)
+		if want, _ := system.Score(uint(position)); err != nil || score != want {
+			t.Fatalf("row %d score = %q, want %g", idx+1, record[1], want)
+		}
+	}
+}
+
+// failingWriter fails every write, for testing that write errors are propagated.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteCSVPropagatesErrors(t *testing.T) {
+	if err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).WriteCSV(failingWriter{}); err == nil {
+		t.Error("WriteCSV to a failing writer succeeded, want an error")
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(system); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded *System
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	for position := uint(1); position <= 500; position++ {
+		want, _ := system.Score(position)
+		if got, _ := decoded.Score(position); got != want {
+			t.Fatalf("decoded Score(%d) = %g, want %g", position, got, want)
+		}
+	}
+}
+
+func TestGobRejectsInvalidParameters(t *testing.T) {
+	data, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// overwrite coeff with 2.
+	binary.LittleEndian.PutUint64(data[20:], math.Float64bits(2))
+
+	var decoded System
+	if err := decoded.GobDecode(data); !errors.Is(err, CoefficientOutOfRangeErr) {
+		t.Errorf("GobDecode = %v, want CoefficientOutOfRangeErr", err)
+	}
+}
+
+func TestBinaryRejectsUnencodableSystems(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	overridden, err := system.WithOverride(1, 50000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cubic, err := NewCubic(500, 1000, 100000, 0.5, 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unbounded, err := NewUnbounded(500, -100, 0, 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		system *System
+	}{
+		{"overridden", overridden},
+		{"cubic", cubic},
+		{"unbounded", unbounded},
+	}
+
+	options := []struct {
+		name   string
+		option Option
+	}{
+		{"reversed", WithReversed(true)},
+		{"quantum", WithQuantum(50)},
+		{"rounded control", WithRoundedControl(true)},
+		{"floor", WithFloorBelow(400, 2000)},
+		{"score cap", WithScoreCap(90000)},
+		{"decay", WithMultiplicativeDecay(0.9)},
+		{"exponent mode", WithExponentMode(ExpFrontLoad)},
+		{"integer scores", WithIntegerScores()},
+	}
+
+	for _, option := range options {
+		optioned, err := system.With(option.option)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests = append(tests, struct {
+			name   string
+			system *System
+		}{option.name, optioned})
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := test.system.MarshalBinary(); err == nil {
+				t.Error("MarshalBinary succeeded, want an error")
+			}
+
+			if err := gob.NewEncoder(io.Discard).Encode(test.system); err == nil {
+				t.Error("gob encoding succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestWriteJSONArray(t *testing.T) {
+	for _, participantCount := range []uint{2, 500} {
+		system := newSystem(t, participantCount, 1000, 100000, 0.5, 1.33)
+
+		var buf bytes.Buffer
+		if err := system.WriteJSONArray(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []float64
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("WriteJSONArray produced invalid JSON %q: %v", buf.String(), err)
+		}
+
+		want := make([]float64, participantCount)
+		system.ScoreAll(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("WriteJSONArray with %d participants did not match ScoreAll", participantCount)
+		}
+	}
+
+	if err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).WriteJSONArray(failingWriter{}); err == nil {
+		t.Error("WriteJSONArray to a failing writer succeeded, want an error")
+	}
+}
+
+func TestFingerprintStable(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	// a fixed value, so that a change to the hashed layout is caught rather than silently changing every fingerprint.
+	if got, want := system.Fingerprint(), uint64(14495885092165035871); got != want {
+		t.Errorf("Fingerprint() = %d, want %d", got, want)
+	}
+
+	if same := newSystem(t, 500, 1000, 100000, 0.5, 1.33); same.Fingerprint() != system.Fingerprint() {
+		t.Error("equal Systems have different fingerprints")
+	}
+
+	var buf bytes.Buffer
+	withLogger, err := system.With(WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if withLogger.Fingerprint() != system.Fingerprint() {
+		t.Error("a logger changed the fingerprint")
+	}
+}
+
+func TestFingerprintSensitive(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"participantCount", WithParticipantCount(501)},
+		{"scoreMin", WithScoreRange(1001, 100000)},
+		{"scoreMax", WithScoreRange(1000, 100001)},
+		{"coeff", WithCoefficient(0.51)},
+		{"exp", WithExponent(1.34)},
+		{"reversed", WithReversed(true)},
+		{"quantum", WithQuantum(10)},
+		{"rounded control", WithRoundedControl(true)},
+		{"floor", WithFloorBelow(400, 2000)},
+		{"score cap", WithScoreCap(90000)},
+		{"integer scores", WithIntegerScores()},
+		{"exponent mode", WithExponentMode(ExpFrontLoad)},
+		{"decay", WithMultiplicativeDecay(0.99)},
+	}
+
+	seen := map[uint64]string{system.Fingerprint(): "original"}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changed, err := system.With(test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fingerprint := changed.Fingerprint()
+			if other, found := seen[fingerprint]; found {
+				t.Errorf("fingerprint %d is shared with %s", fingerprint, other)
+			}
+
+			seen[fingerprint] = test.name
+		})
+	}
+
+	overridden, err := system.WithOverride(1, 95000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if overridden.Fingerprint() == system.Fingerprint() {
+		t.Error("an override did not change the fingerprint")
+	}
+}
+
+func TestScoresBytesRoundTrip(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	data := system.ScoresBytes()
+	if len(data) != 8*500 {
+		t.Fatalf("len(ScoresBytes()) = %d, want %d", len(data), 8*500)
+	}
+
+	if first := math.Float64frombits(binary.BigEndian.Uint64(data)); first != 100000 {
+		t.Errorf("first encoded score = %g, want 100000 in big-endian", first)
+	}
+
+	scores, err := DecodeScores(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := system.AppendScores(nil); !slices.Equal(scores, want) {
+		t.Error("DecodeScores(ScoresBytes()) differs from AppendScores")
+	}
+
+	if scores, err := DecodeScores(nil); err != nil || len(scores) != 0 {
+		t.Errorf("DecodeScores(nil) = %v, %v, want no scores", scores, err)
+	}
+}
+
+func TestDecodeScoresRejectsBadLength(t *testing.T) {
+	data := newSystem(t, 5, 1000, 100000, 0.5, 1.33).ScoresBytes()
+	for _, length := range []int{1, 7, 9, len(data) - 1} {
+		if scores, err := DecodeScores(data[:length]); err == nil {
+			t.Errorf("DecodeScores of %d bytes = %v, want an error", length, scores)
+		}
+	}
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
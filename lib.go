@@ -1,104 +1,1530 @@
 package bezierscore
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"maps"
 	"math"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 var (
 	ParticipantCountOutOfRangeErr = errors.New("participantCount must be at least 2")
+	ParticipantCountTooLargeErr   = errors.New("participantCount must be at most MaxParticipantCount")
 	ScoreMinOutOfRangeErr         = errors.New("scoreMin must be at least 1")
 	ScoreMaxOutOfRangeErr         = errors.New("scoreMax must be more than scoreMin")
 	CoefficientOutOfRangeErr      = errors.New("coeff must be between 0 and 1 inclusive")
 	ExponentOutOfRangeErr         = errors.New("exp must be at least 1")
+	ControlPointOutOfRangeErr     = errors.New("controlPoint must be between scoreMin and scoreMax inclusive")
+	EasingOutOfRangeErr           = errors.New("ease must map [0, 1] onto [0, 1]")
+	QuantumOutOfRangeErr          = errors.New("quantum must be at least 0")
+	SplitPositionOutOfRangeErr    = errors.New("splitPosition must be between 2 and participantCount-1 inclusive")
+	NilSystemErr                  = errors.New("Systems must not be nil")
+	NonFiniteParameterErr         = errors.New("parameters must be finite")
+	BufferLengthErr               = errors.New("len(buf) must equal participantCount")
+	BinCountOutOfRangeErr         = errors.New("bins must be at least 1")
+	TopNOutOfRangeErr             = errors.New("n must be between 1 and participantCount inclusive")
+	BlendWeightOutOfRangeErr      = errors.New("t must be between 0 and 1 inclusive")
+	ParticipantCountMismatchErr   = errors.New("a and b must have the same participantCount")
+	BlendUnsupportedErr           = errors.New("a and b must not have a custom easing function or be piecewise")
+	BlendKindMismatchErr          = errors.New("a and b must be the same kind of curve")
+	BlendOptionsMismatchErr       = errors.New("a and b must have the same options")
+	FloorScoreOutOfRangeErr       = errors.New("floor score must be between scoreMin and scoreMax inclusive")
+	FloorPositionOutOfRangeErr    = errors.New("floor position must be less than participantCount")
+	PercentOutOfRangeErr          = errors.New("percents must each be between 0 and 1 inclusive")
+	ScoreCapOutOfRangeErr         = errors.New("scoreCap must be at least 0")
+	ShareOutOfRangeErr            = errors.New("topShare and bottomShare must be between 0 and 1 inclusive")
+	DecayRateOutOfRangeErr        = errors.New("decay rate must be at least 0 and less than 1")
+	ExponentModeOutOfRangeErr     = errors.New("exponent mode must be ExpBackLoad or ExpFrontLoad")
+	SampleCountOutOfRangeErr      = errors.New("n must be at least 2")
+	MiddleOutOfRangeErr           = errors.New("middle must be between last and first exclusive")
+	OverridePositionOutOfRangeErr = errors.New("override position must be between 1 and participantCount inclusive")
+	OverrideScoreOutOfRangeErr    = errors.New("override score must be between scoreMin and scoreMax inclusive")
 )
 
-func bezier(from, to, control, alpha float64) float64 {
-	return (from * math.Pow(1.0-alpha, 2)) + (alpha * control * 2 * (1.0 - alpha)) + (math.Pow(alpha, 2) * to)
+// MaxParticipantCount is the largest participantCount a System accepts. Below it, every position converts exactly to
+// float64 for the curve math, and to int for indexing buffers, on every platform.
+const MaxParticipantCount = math.MaxInt32
+
+// ValidationError is returned when a parameter is out of range. Err is one of the sentinel errors above, so
+// errors.Is(err, CoefficientOutOfRangeErr) and friends still match.
+type ValidationError struct {
+	Field string
+	Value float64
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v, got %s=%g", e.Err, e.Field, e.Value)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError is returned by NewBatch when one of its configs is invalid. Index identifies the offending config, and Err
+// is the error New returned for it.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("config %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// floating is the set of types the curve math can operate in.
+type floating interface {
+	~float32 | ~float64
+}
+
+func bezier[T floating](from, to, control, alpha T) T {
+	return ((1.0 - alpha) * (1.0 - alpha) * from) + (2 * alpha * (1.0 - alpha) * control) + (alpha * alpha * to)
+}
+
+// bezierDerivative is the derivative of bezier with respect to alpha.
+func bezierDerivative[T floating](from, to, control, alpha T) T {
+	return (2 * (1.0 - alpha) * (control - from)) + (2 * alpha * (to - control))
+}
+
+func cubicBezier(from, to, control1, control2, alpha float64) float64 {
+	inverse := 1.0 - alpha
+	return (inverse * inverse * inverse * from) +
+		(3 * inverse * inverse * alpha * control1) +
+		(3 * inverse * alpha * alpha * control2) +
+		(alpha * alpha * alpha * to)
+}
+
+// cubicBezierDerivative is the derivative of cubicBezier with respect to alpha.
+func cubicBezierDerivative(from, to, control1, control2, alpha float64) float64 {
+	inverse := 1.0 - alpha
+	return (3 * inverse * inverse * (control1 - from)) +
+		(6 * inverse * alpha * (control2 - control1)) +
+		(3 * alpha * alpha * (to - control2))
 }
 
+// System computes Bezier scores for the positions of a leaderboard.
+//
+// A System is immutable once constructed: apart from the Unmarshal decoders, no method modifies it, and every derived
+// value is computed up front by New and friends. It is therefore safe for concurrent use by multiple goroutines.
+// Methods like With return a new System rather than changing the receiver.
 type System struct {
 	participantCount   uint
 	upperBound         float64
 	lowerBound         float64
 	controlCoefficient float64
 	exponent           float64
+	reversed           bool
+	quantum            float64
+	roundControl       bool
+
+	// unbounded is set by NewUnbounded, in which case scoreMin may be less than 1.
+	unbounded bool
+
+	// floorPosition and floorScore are set by WithFloorBelow. Every position worse than floorPosition scores
+	// floorScore, unless floorPosition is 0.
+	floorPosition uint
+	floorScore    float64
+
+	// scoreCap is set by WithScoreCap. No score exceeds it, unless it is 0.
+	scoreCap float64
+
+	// exponentMode is set by WithExponentMode, and selects how exponent warps alpha.
+	exponentMode ExponentMode
+
+	// integerScores is set by WithIntegerScores, in which case every score is rounded to an integer.
+	integerScores bool
+
+	// decayRate is set by WithMultiplicativeDecay, in which case it replaces the curve, unless it is 0.
+	decayRate float64
+
+	// overrides is set by WithOverride, mapping positions onto the scores they are awarded in place of the curve. It is
+	// never modified once the System is constructed, so copies of the System may share it.
+	overrides map[uint]float64
+
+	// logger is set by WithLogger. It is nil by default, in which case nothing is logged.
+	logger *slog.Logger
+
+	// cubic is set by NewCubic, in which case controlCoefficient2 shapes a second control point.
+	cubic               bool
+	controlCoefficient2 float64
+
+	// ease is set by NewWithEasing, in which case it replaces the exponent when warping alpha.
+	ease func(float64) float64
+
+	// piecewise is set by NewPiecewise, in which case scores come from its Systems rather than this System's curve.
+	piecewise *piecewise
+
+	// explicitControl is set by NewWithControl, in which case controlPoint was given directly rather than computed
+	// from controlCoefficient.
+	explicitControl bool
+
+	// controlPoint and controlPoint2 cache control() and control2(), which only depend on the parameters above.
+	controlPoint  float64
+	controlPoint2 float64
+}
+
+func New(participantCount uint, scoreMin, scoreMax, coeff, exp float64) (*System, error) {
+	s := &System{
+		participantCount:   participantCount,
+		upperBound:         scoreMax,
+		lowerBound:         scoreMin,
+		controlCoefficient: coeff,
+		exponent:           exp,
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.prepare()
+	return s, nil
+}
+
+// Config holds the parameters of New, for constructing many Systems at once with NewBatch.
+type Config struct {
+	ParticipantCount uint
+	ScoreMin         float64
+	ScoreMax         float64
+	Coefficient      float64
+	Exponent         float64
+}
+
+// NewBatch constructs a System for every config, in the same order. If any config is invalid, NewBatch returns a
+// *BatchError identifying the first invalid config and no Systems.
+func NewBatch(configs []Config) ([]*System, error) {
+	systems := make([]*System, len(configs))
+	for idx, config := range configs {
+		system, err := New(config.ParticipantCount, config.ScoreMin, config.ScoreMax, config.Coefficient, config.Exponent)
+		if err != nil {
+			return nil, &BatchError{Index: idx, Err: err}
+		}
+
+		systems[idx] = system
+	}
+
+	return systems, nil
+}
+
+// MustNew is like New, but panics if the parameters are invalid. It is intended for tests and package-level variable
+// initialization, where the parameters are known to be valid.
+func MustNew(participantCount uint, scoreMin, scoreMax, coeff, exp float64) *System {
+	s, err := New(participantCount, scoreMin, scoreMax, coeff, exp)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// NewCubic constructs a System whose scores follow a cubic bezier curve with two control points, rather than the
+// quadratic curve used by New.
+//
+// coeff1 blends the first control point between the middle of the score range and scoreMax, just like coeff does for
+// New. coeff2 blends the second control point between the middle of the score range and scoreMin. Both must be
+// between 0 and 1 inclusive. With both at 0 the curve is an S-curve, steep at both ends and flat in the middle.
+func NewCubic(participantCount uint, scoreMin, scoreMax, coeff1, coeff2, exp float64) (*System, error) {
+	s := &System{
+		participantCount:    participantCount,
+		upperBound:          scoreMax,
+		lowerBound:          scoreMin,
+		controlCoefficient:  coeff1,
+		exponent:            exp,
+		cubic:               true,
+		controlCoefficient2: coeff2,
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.prepare()
+	return s, nil
+}
+
+// NewWithControl constructs a System that uses controlPoint directly as the control point of its bezier curve, rather
+// than deriving it from a coefficient. controlPoint must be between scoreMin and scoreMax inclusive.
+//
+// A controlPoint halfway between scoreMin and scoreMax produces the same curve as a coeff of 0.
+func NewWithControl(participantCount uint, scoreMin, scoreMax, controlPoint, exp float64) (*System, error) {
+	s := &System{
+		participantCount: participantCount,
+		upperBound:       scoreMax,
+		lowerBound:       scoreMin,
+		exponent:         exp,
+		explicitControl:  true,
+		controlPoint:     controlPoint,
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.prepare()
+	return s, nil
+}
+
+// easingSamples are the linear alphas at which NewWithEasing checks that ease stays within [0, 1].
+var easingSamples = [...]float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 1}
+
+// NewWithEasing constructs a System whose alpha is warped by ease rather than by an exponent. ease receives the linear
+// alpha of a position, in [0, 1], and must return a warped alpha also in [0, 1]. Exponent reports 1 for such Systems.
+//
+// ease is validated by sampling it at a handful of points, so it is the caller's responsibility to ensure it stays
+// within [0, 1] everywhere else. A nil ease leaves alpha linear, like an exp of 1.
+//
+// example:
+//
+//	sigmoid := func(alpha float64) float64 {
+//		return 1 / (1 + math.Exp(-12*(alpha-0.5)))
+//	}
+//
+//	system, err := bezierscore.NewWithEasing(500, 1000, 100000, 0.5, sigmoid)
+func NewWithEasing(participantCount uint, scoreMin, scoreMax, coeff float64, ease func(float64) float64) (*System, error) {
+	s := &System{
+		participantCount:   participantCount,
+		upperBound:         scoreMax,
+		lowerBound:         scoreMin,
+		controlCoefficient: coeff,
+		exponent:           1,
+		ease:               ease,
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.prepare()
+	return s, nil
+}
+
+// prepare caches values derived from the System's parameters. It must be called whenever the parameters change.
+func (s *System) prepare() {
+	if !s.explicitControl {
+		s.controlPoint = s.roundedControl(s.control())
+	}
+
+	s.controlPoint2 = s.roundedControl(s.control2())
+}
+
+// roundedControl rounds a computed control point to the nearest integer, or to the nearest multiple of quantum if one
+// is set, when the System has the rounded control option. The result is kept within the score range.
+func (s *System) roundedControl(control float64) float64 {
+	if !s.roundControl {
+		return control
+	}
+
+	step := 1.0
+	if s.quantum > 0 {
+		step = s.quantum
+	}
+
+	rounded := math.Round(control/step) * step
+	return min(max(rounded, s.lowerBound), s.upperBound)
+}
+
+// piecewise splits a leaderboard into two segments, each scored by its own System.
+type piecewise struct {
+	split  uint
+	top    *System
+	bottom *System
+}
+
+// NewPiecewise constructs a System that scores positions 1 through splitPosition along topSystem's curve, and
+// positions splitPosition through participantCount along bottomSystem's curve. Each segment is stretched over the whole
+// of its System's curve, from first place to last place, so topSystem and bottomSystem's own participant counts do not
+// matter. Their output options, such as quantization, still apply.
+//
+// splitPosition belongs to both segments and scores topSystem's last place. The curve is therefore continuous when
+// topSystem's last place scores the same as bottomSystem's first place, typically by making topSystem's scoreMin equal
+// to bottomSystem's scoreMax. Otherwise the scores jump between splitPosition and the position after it.
+//
+// splitPosition must be between 2 and participantCount-1 inclusive. Options applied with With that shape the curve,
+// like WithCoefficient, have no effect on a piecewise System, while output options like WithQuantum do.
+//
+// example:
+//
+//	top, _    := bezierscore.New(50, 10000, 100000, 0.5, 1.33)
+//	bottom, _ := bezierscore.New(450, 1000, 10000, 0, 1)
+//	system, _ := bezierscore.NewPiecewise(500, 50, top, bottom)
+func NewPiecewise(participantCount uint, splitPosition uint, topSystem, bottomSystem *System) (*System, error) {
+	if topSystem == nil || bottomSystem == nil {
+		return nil, NilSystemErr
+	}
+
+	s := &System{
+		participantCount: participantCount,
+		upperBound:       max(topSystem.upperBound, bottomSystem.upperBound),
+		lowerBound:       min(topSystem.lowerBound, bottomSystem.lowerBound),
+		exponent:         1,
+		unbounded:        topSystem.unbounded || bottomSystem.unbounded,
+		piecewise:        &piecewise{split: splitPosition, top: topSystem, bottom: bottomSystem},
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.prepare()
+	return s, nil
+}
+
+// segment maps numerator/denominator of the way along a piecewise System onto the System scoring that segment,
+// returning that System and how far along its own leaderboard the fraction lies.
+func (s *System) segment(numerator, denominator float64) (*System, float64, float64) {
+	if s.reversed {
+		numerator = denominator - numerator
+	}
+
+	// offset is how many positions past first place the fraction lies. it is computed exactly for whole positions.
+	offset := numerator
+	if total := float64(s.participantCount - 1); denominator != total {
+		offset = numerator * total / denominator
+	}
+
+	split := float64(s.piecewise.split - 1)
+	if offset <= split {
+		return s.piecewise.top, offset, split
+	}
+
+	return s.piecewise.bottom, offset - split, float64(s.participantCount-1) - split
+}
+
+// NewUnbounded constructs a System like New, except that scoreMin may be less than 1, including 0 and negative scores.
+// scoreMax must still be more than scoreMin, and every parameter must still be finite.
+//
+// example:
+//
+//	golf, err := bezierscore.NewUnbounded(participantCount, -100, 0, coeff, exp)
+func NewUnbounded(participantCount uint, scoreMin, scoreMax, coeff, exp float64) (*System, error) {
+	s := &System{
+		participantCount:   participantCount,
+		upperBound:         scoreMax,
+		lowerBound:         scoreMin,
+		controlCoefficient: coeff,
+		exponent:           exp,
+		unbounded:          true,
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.prepare()
+	return s, nil
+}
+
+// NewSimple constructs a System with the default coeff of 0.5 and exp of 1. See New.
+func NewSimple(participantCount uint, scoreMin, scoreMax float64) (*System, error) {
+	return New(participantCount, scoreMin, scoreMax, 0.5, 1.0)
+}
+
+// NewInt constructs a System like New, for leaderboards whose score bounds are whole numbers. The bounds are stored as
+// float64, so they are only exact up to 2^53. Pair it with ScoreInt to keep scores whole. See New.
+func NewInt(participantCount uint, scoreMin, scoreMax int64, coeff, exp float64) (*System, error) {
+	return New(participantCount, float64(scoreMin), float64(scoreMax), coeff, exp)
+}
+
+// NewFromPool constructs a System like New, deriving its score range from a prize pool: first place is awarded
+// pool*topShare and last place pool*bottomShare. The derived scoreMin and scoreMax are validated the same way New
+// validates them.
+//
+// example:
+//
+//	// first place takes 25% of a 100,000 pool, and last place 0.1%.
+//	system, err := bezierscore.NewFromPool(500, 100000, 0.25, 0.001, 0.5, 1.33)
+func NewFromPool(participantCount uint, pool, topShare, bottomShare, coeff, exp float64) (*System, error) {
+	if !(topShare >= 0 && topShare <= 1) {
+		return nil, &ValidationError{"topShare", topShare, ShareOutOfRangeErr}
+	}
+
+	if !(bottomShare >= 0 && bottomShare <= 1) {
+		return nil, &ValidationError{"bottomShare", bottomShare, ShareOutOfRangeErr}
+	}
+
+	return New(participantCount, pool*bottomShare, pool*topShare, coeff, exp)
+}
+
+// Fit constructs a System that scores first for first place, last for last place, and middle halfway down the
+// leaderboard, for reproducing an existing leaderboard from a few of its scores. first and last become scoreMax and
+// scoreMin, and are validated the same way New validates them. middle must be strictly between them.
+//
+// The fit is solved in closed form at the midpoint of the curve, where the linear alpha is 0.5, so it is exact up to
+// float64 rounding. That midpoint is a whole position when participantCount is odd; otherwise middle falls between the
+// scores of the two middle positions. With m the midpoint of the score range:
+//
+//	middle between m and (3*first + last) / 4:  exp of 1, with coeff solved to put the control point where needed
+//	middle above (3*first + last) / 4:          coeff of 1, with exp solved to hold scores up for longer
+//	middle below m:                             coeff of 0, with exp solved as in ExpFrontLoad to drop scores sooner
+func Fit(participantCount uint, first, middle, last float64) (*System, error) {
+	system, err := New(participantCount, last, first, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if !(middle > last && middle < first) {
+		return nil, &ValidationError{"middle", middle, MiddleOutOfRangeErr}
+	}
+
+	// at alpha 0.5, a quadratic curve scores (first + 2*control + last) / 4.
+	span := first - last
+	half := system.middle()
+	switch upperQuarter := first - (span / 4); {
+	case middle >= half && middle <= upperQuarter:
+		// clamp away rounding error at the boundaries between cases, here and below.
+		control := (2 * middle) - half
+		return system.With(WithCoefficient(min(max((control-half)/(first-half), 0), 1)))
+	case middle > upperQuarter:
+		// with the control point at first, the curve scores first - span*alpha^2, and alpha = 0.5^exp.
+		alpha := math.Sqrt((first - middle) / span)
+		return system.With(WithCoefficient(1), WithExponent(max(math.Log(alpha)/math.Log(0.5), 1)))
+	default:
+		// with the control point at m, the curve is linear, scoring first - span*alpha, and alpha = 1 - 0.5^exp.
+		alpha := (first - middle) / span
+		return system.With(WithExponent(max(math.Log(1-alpha)/math.Log(0.5), 1)), WithExponentMode(ExpFrontLoad))
+	}
+}
+
+// Blend constructs a System whose parameters are interpolated linearly between those of a and b, weighted by t. A t of
+// 0 reproduces a, a t of 1 reproduces b, and values in between transition smoothly from one season's curve to the
+// next.
+//
+// The parameters are interpolated, not the scores, so a blend is not guaranteed to score every position between its
+// scores in a and b. When a and b pull the curve in opposite directions, such as a low coeff with a high exp, some
+// positions can score well outside both. Callers that need every score between the two should interpolate the scores
+// of a and b directly instead.
+//
+// scoreMin, scoreMax, coeff, exp, the floor score, and the second coeff or explicit control point where present, are
+// interpolated. a and b must otherwise match: they must have the same participantCount, be of the same kind of curve,
+// and have the same options, otherwise ParticipantCountMismatchErr, BlendKindMismatchErr or BlendOptionsMismatchErr is
+// returned. Systems constructed with NewWithEasing or NewPiecewise cannot be blended, and return BlendUnsupportedErr. t
+// must be between 0 and 1 inclusive, otherwise a *ValidationError wrapping BlendWeightOutOfRangeErr is returned.
+func Blend(a, b *System, t float64) (*System, error) {
+	if a == nil || b == nil {
+		return nil, NilSystemErr
+	}
+
+	if !(t >= 0 && t <= 1) {
+		return nil, &ValidationError{"t", t, BlendWeightOutOfRangeErr}
+	}
+
+	if a.participantCount != b.participantCount {
+		return nil, ParticipantCountMismatchErr
+	}
+
+	if a.ease != nil || b.ease != nil || a.piecewise != nil || b.piecewise != nil {
+		return nil, BlendUnsupportedErr
+	}
+
+	if a.cubic != b.cubic || a.explicitControl != b.explicitControl {
+		return nil, BlendKindMismatchErr
+	}
+
+	if a.reversed != b.reversed || a.unbounded != b.unbounded || a.roundControl != b.roundControl ||
+		a.quantum != b.quantum || a.floorPosition != b.floorPosition || a.scoreCap != b.scoreCap ||
+		a.decayRate != b.decayRate || a.exponentMode != b.exponentMode || a.integerScores != b.integerScores ||
+		!maps.Equal(a.overrides, b.overrides) {
+		return nil, BlendOptionsMismatchErr
+	}
+
+	// (1-t)*a + t*b rather than a + t*(b-a), so that the endpoints reproduce a and b exactly.
+	lerp := func(from, to float64) float64 {
+		return ((1 - t) * from) + (t * to)
+	}
+
+	s := &System{
+		participantCount:    a.participantCount,
+		upperBound:          lerp(a.upperBound, b.upperBound),
+		lowerBound:          lerp(a.lowerBound, b.lowerBound),
+		controlCoefficient:  lerp(a.controlCoefficient, b.controlCoefficient),
+		exponent:            lerp(a.exponent, b.exponent),
+		reversed:            a.reversed,
+		quantum:             a.quantum,
+		roundControl:        a.roundControl,
+		unbounded:           a.unbounded,
+		floorPosition:       a.floorPosition,
+		floorScore:          lerp(a.floorScore, b.floorScore),
+		scoreCap:            a.scoreCap,
+		decayRate:           a.decayRate,
+		exponentMode:        a.exponentMode,
+		integerScores:       a.integerScores,
+		overrides:           a.overrides,
+		cubic:               a.cubic,
+		controlCoefficient2: lerp(a.controlCoefficient2, b.controlCoefficient2),
+		explicitControl:     a.explicitControl,
+	}
+
+	if s.explicitControl {
+		s.controlPoint = lerp(a.controlPoint, b.controlPoint)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.prepare()
+	return s, nil
+}
+
+// Validate checks the System's parameters against the invariants enforced by New and the other constructors, returning
+// the first violation as a *ValidationError wrapping the corresponding sentinel error, or nil if the System is valid.
+//
+// Every constructor and decoder already calls Validate, so it is only needed for Systems obtained by other means.
+func (s *System) Validate() error {
+	params := []struct {
+		field string
+		value float64
+	}{
+		{"scoreMin", s.lowerBound},
+		{"scoreMax", s.upperBound},
+		{"coeff", s.controlCoefficient},
+		{"exp", s.exponent},
+		{"coeff2", s.controlCoefficient2},
+		{"controlPoint", s.controlPoint},
+		{"quantum", s.quantum},
+		{"floorScore", s.floorScore},
+		{"scoreCap", s.scoreCap},
+		{"decayRate", s.decayRate},
+	}
+
+	for _, param := range params {
+		if math.IsNaN(param.value) || math.IsInf(param.value, 0) {
+			return &ValidationError{param.field, param.value, NonFiniteParameterErr}
+		}
+	}
+
+	if s.participantCount < 2 {
+		return &ValidationError{"participantCount", float64(s.participantCount), ParticipantCountOutOfRangeErr}
+	}
+
+	if s.participantCount > MaxParticipantCount {
+		return &ValidationError{"participantCount", float64(s.participantCount), ParticipantCountTooLargeErr}
+	}
+
+	if !s.unbounded && s.lowerBound < 1 {
+		return &ValidationError{"scoreMin", s.lowerBound, ScoreMinOutOfRangeErr}
+	}
+
+	if s.upperBound <= s.lowerBound {
+		return &ValidationError{"scoreMax", s.upperBound, ScoreMaxOutOfRangeErr}
+	}
+
+	if s.controlCoefficient < 0 || s.controlCoefficient > 1 {
+		return &ValidationError{"coeff", s.controlCoefficient, CoefficientOutOfRangeErr}
+	}
+
+	if s.exponent < 1 {
+		return &ValidationError{"exp", s.exponent, ExponentOutOfRangeErr}
+	}
+
+	if s.cubic && (s.controlCoefficient2 < 0 || s.controlCoefficient2 > 1) {
+		return &ValidationError{"coeff2", s.controlCoefficient2, CoefficientOutOfRangeErr}
+	}
+
+	if s.explicitControl && (s.controlPoint < s.lowerBound || s.controlPoint > s.upperBound) {
+		return &ValidationError{"controlPoint", s.controlPoint, ControlPointOutOfRangeErr}
+	}
+
+	if s.quantum < 0 {
+		return &ValidationError{"quantum", s.quantum, QuantumOutOfRangeErr}
+	}
+
+	for position, score := range s.overrides {
+		if position == 0 || position > s.participantCount {
+			return &ValidationError{"overridePosition", float64(position), OverridePositionOutOfRangeErr}
+		}
+
+		if !(score >= s.lowerBound && score <= s.upperBound) {
+			return &ValidationError{"overrideScore", score, OverrideScoreOutOfRangeErr}
+		}
+	}
+
+	if s.exponentMode != ExpBackLoad && s.exponentMode != ExpFrontLoad {
+		return &ValidationError{"exponentMode", float64(s.exponentMode), ExponentModeOutOfRangeErr}
+	}
+
+	if s.decayRate < 0 || s.decayRate >= 1 {
+		return &ValidationError{"decayRate", s.decayRate, DecayRateOutOfRangeErr}
+	}
+
+	if s.scoreCap < 0 {
+		return &ValidationError{"scoreCap", s.scoreCap, ScoreCapOutOfRangeErr}
+	}
+
+	if s.floorPosition >= s.participantCount {
+		return &ValidationError{"floorPosition", float64(s.floorPosition), FloorPositionOutOfRangeErr}
+	}
+
+	if s.floorPosition > 0 && (s.floorScore < s.lowerBound || s.floorScore > s.upperBound) {
+		return &ValidationError{"floorScore", s.floorScore, FloorScoreOutOfRangeErr}
+	}
+
+	if s.piecewise != nil {
+		if s.piecewise.top == nil || s.piecewise.bottom == nil {
+			return NilSystemErr
+		}
+
+		if s.piecewise.split < 2 || s.piecewise.split >= s.participantCount {
+			return &ValidationError{"splitPosition", float64(s.piecewise.split), SplitPositionOutOfRangeErr}
+		}
+	}
+
+	if s.ease != nil {
+		for _, sample := range easingSamples {
+			if eased := s.ease(sample); !(eased >= 0 && eased <= 1) {
+				return &ValidationError{"ease", eased, EasingOutOfRangeErr}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParticipantCount returns the participantCount the System was constructed with.
+func (s *System) ParticipantCount() uint {
+	return s.participantCount
+}
+
+// ScoreMin returns the score awarded to last place, as passed to New.
+func (s *System) ScoreMin() float64 {
+	return s.lowerBound
+}
+
+// ScoreMax returns the score awarded to first place, as passed to New.
+func (s *System) ScoreMax() float64 {
+	return s.upperBound
+}
+
+// Coefficient returns the control coefficient the System was constructed with. It is 0 for Systems constructed with
+// NewWithControl.
+func (s *System) Coefficient() float64 {
+	return s.controlCoefficient
+}
+
+// Exponent returns the exponent the System was constructed with. It is 1 for Systems constructed with NewWithEasing.
+func (s *System) Exponent() float64 {
+	return s.exponent
+}
+
+// ControlPoint returns the control point of the System's bezier curve, the first of the two for cubic Systems. Unless
+// it was given to NewWithControl, it is computed from coeff as
+//
+//	middle       = (scoreMin + scoreMax) / 2
+//	controlPoint = (1-coeff)*middle + coeff*scoreMax
+//
+// and then rounded if the System has the rounded control option. Systems constructed with NewPiecewise score along the
+// curves of their own Systems, so their control point is not used.
+func (s *System) ControlPoint() float64 {
+	return s.controlPoint
+}
+
+// String implements fmt.Stringer, formatting the System's parameters by name, e.g.
+//
+//	bezierscore.System{participants:500, min:1000, max:100000, coeff:0.5, exp:1.33}
+//
+// Cubic Systems additionally include coeff2 after coeff, Systems constructed with NewWithControl include control in
+// place of coeff, and Systems constructed with NewWithEasing include ease:custom in place of exp. Systems constructed
+// with NewPiecewise format their split position and both of their Systems instead.
+//
+// Options that differ from their defaults follow, named by the same keys as MarshalText, e.g.
+//
+//	bezierscore.System{participants:500, min:1000, max:100000, coeff:0.5, exp:1.33, reversed:true, quantum:50}
+func (s *System) String() string {
+	if s.piecewise != nil {
+		return fmt.Sprintf(
+			"bezierscore.System{participants:%d, split:%d, top:%v, bottom:%v%s}",
+			s.participantCount,
+			s.piecewise.split,
+			s.piecewise.top,
+			s.piecewise.bottom,
+			s.optionsString(),
+		)
+	}
+
+	shape := fmt.Sprintf("coeff:%g", s.controlCoefficient)
+	if s.explicitControl {
+		shape = fmt.Sprintf("control:%g", s.controlPoint)
+	}
+
+	if s.cubic {
+		shape += fmt.Sprintf(", coeff2:%g", s.controlCoefficient2)
+	}
+
+	warp := fmt.Sprintf("exp:%g", s.exponent)
+	if s.ease != nil {
+		warp = "ease:custom"
+	}
+
+	return fmt.Sprintf(
+		"bezierscore.System{participants:%d, min:%g, max:%g, %s, %s%s}",
+		s.participantCount,
+		s.lowerBound,
+		s.upperBound,
+		shape,
+		warp,
+		s.optionsString(),
+	)
+}
+
+// optionsString formats the options that differ from their defaults for String, each preceded by a comma.
+func (s *System) optionsString() string {
+	var builder strings.Builder
+	if s.exponentMode != ExpBackLoad {
+		fmt.Fprintf(&builder, ", expmode:%d", s.exponentMode)
+	}
+
+	if s.reversed {
+		builder.WriteString(", reversed:true")
+	}
+
+	if s.quantum != 0 {
+		fmt.Fprintf(&builder, ", quantum:%g", s.quantum)
+	}
+
+	if s.unbounded {
+		builder.WriteString(", unbounded:true")
+	}
+
+	if s.roundControl {
+		builder.WriteString(", roundcontrol:true")
+	}
+
+	if s.integerScores {
+		builder.WriteString(", integerscores:true")
+	}
+
+	if s.floorPosition > 0 {
+		fmt.Fprintf(&builder, ", floorposition:%d, floorscore:%g", s.floorPosition, s.floorScore)
+	}
+
+	if s.scoreCap > 0 {
+		fmt.Fprintf(&builder, ", scorecap:%g", s.scoreCap)
+	}
+
+	if s.decayRate > 0 {
+		fmt.Fprintf(&builder, ", decay:%g", s.decayRate)
+	}
+
+	for idx, position := range slices.Sorted(maps.Keys(s.overrides)) {
+		if idx == 0 {
+			builder.WriteString(", overrides:")
+		} else {
+			builder.WriteString(",")
+		}
+
+		fmt.Fprintf(&builder, "%d:%g", position, s.overrides[position])
+	}
+
+	return builder.String()
+}
+
+// equalEpsilon is the relative tolerance Equal uses when comparing float parameters.
+const equalEpsilon = 1e-9
+
+func floatsEqual(a, b float64) bool {
+	scale := math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+	return math.Abs(a-b) <= equalEpsilon*scale
+}
+
+// Equal reports whether s and other were constructed with the same parameters and options. Float parameters are
+// considered equal when they are within a small relative epsilon of each other.
+//
+// Two nil Systems are equal, and a nil System is never equal to a non-nil one. Since functions cannot be compared, a
+// System constructed with NewWithEasing is only equal to itself.
+func (s *System) Equal(other *System) bool {
+	if s == nil || other == nil || s.ease != nil || other.ease != nil {
+		return s == other
+	}
+
+	return s.participantCount == other.participantCount &&
+		floatsEqual(s.lowerBound, other.lowerBound) &&
+		floatsEqual(s.upperBound, other.upperBound) &&
+		floatsEqual(s.controlCoefficient, other.controlCoefficient) &&
+		floatsEqual(s.exponent, other.exponent) &&
+		s.exponentMode == other.exponentMode &&
+		s.integerScores == other.integerScores &&
+		maps.EqualFunc(s.overrides, other.overrides, floatsEqual) &&
+		s.reversed == other.reversed &&
+		floatsEqual(s.quantum, other.quantum) &&
+		s.roundControl == other.roundControl &&
+		s.floorPosition == other.floorPosition &&
+		floatsEqual(s.floorScore, other.floorScore) &&
+		floatsEqual(s.scoreCap, other.scoreCap) &&
+		floatsEqual(s.decayRate, other.decayRate) &&
+		s.cubic == other.cubic &&
+		floatsEqual(s.controlCoefficient2, other.controlCoefficient2) &&
+		s.explicitControl == other.explicitControl &&
+		floatsEqual(s.controlPoint, other.controlPoint) &&
+		s.piecewise.equal(other.piecewise)
+}
+
+func (p *piecewise) equal(other *piecewise) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+
+	return p.split == other.split && p.top.Equal(other.top) && p.bottom.Equal(other.bottom)
+}
+
+// Alpha returns the interpolation parameter of the bezier curve for position, after it has been warped by the
+// exponent or easing function. It is 0 for first place and 1 for last place, or the other way around when reversed.
+//
+// position must be at least 1, and at most the participantCount, just like Score.
+func (s *System) Alpha(position uint) (alpha float64, ok bool) {
+	if position == 0 || position > s.participantCount {
+		return 0, false
+	}
+
+	return s.alphaAt(float64(position-1), float64(s.participantCount-1)), true
+}
+
+// alphaAt returns the interpolation parameter passed to bezier at numerator/denominator of the way from first place to
+// last place. The fraction is mirrored when reversed, and then warped by warp.
+func (s *System) alphaAt(numerator, denominator float64) float64 {
+	if s.piecewise != nil {
+		child, numerator, denominator := s.segment(numerator, denominator)
+		return child.alphaAt(numerator, denominator)
+	}
+
+	if s.reversed {
+		numerator = denominator - numerator
+	}
+
+	return s.warp(numerator / denominator)
+}
+
+// scoreAt returns the score at numerator/denominator of the way from first place to last place, with output options
+// applied. Positions map onto numerator position-1 and denominator participantCount-1.
+func (s *System) scoreAt(numerator, denominator float64) float64 {
+	if s.overrides != nil {
+		// only whole positions can be overridden.
+		offset := numerator * float64(s.participantCount-1) / denominator
+		if score, found := s.overrides[uint(offset)+1]; found && offset == math.Trunc(offset) {
+			return s.adjust(score)
+		}
+	}
+
+	if s.floored(numerator, denominator) {
+		return s.adjust(s.floorScore)
+	}
+
+	return s.adjust(s.rawAt(numerator, denominator))
+}
+
+// floored reports whether numerator/denominator of the way from first place to last place is worse than the
+// floorPosition set by WithFloorBelow. The fractions are cross-multiplied so that whole positions compare exactly.
+func (s *System) floored(numerator, denominator float64) bool {
+	if s.floorPosition == 0 {
+		return false
+	}
+
+	return numerator*float64(s.participantCount-1) > float64(s.floorPosition-1)*denominator
+}
+
+// rawAt returns the score of the curve at numerator/denominator of the way from first place to last place, before
+// output options are applied.
+func (s *System) rawAt(numerator, denominator float64) float64 {
+	if s.decayRate > 0 {
+		return s.decay(s.decaySteps(numerator, denominator))
+	}
+
+	if s.piecewise != nil {
+		child, numerator, denominator := s.segment(numerator, denominator)
+		return child.scoreAt(numerator, denominator)
+	}
+
+	return s.curve(s.alphaAt(numerator, denominator))
+}
+
+// decaySteps returns how many ranks below first place numerator/denominator of the way from first place to last place
+// is, mirrored when reversed, for WithMultiplicativeDecay.
+func (s *System) decaySteps(numerator, denominator float64) float64 {
+	if s.reversed {
+		numerator = denominator - numerator
+	}
+
+	return numerator * float64(s.participantCount-1) / denominator
+}
+
+// decay returns scoreMax reduced by decayRate steps times, but never less than scoreMin.
+func (s *System) decay(steps float64) float64 {
+	return max(s.upperBound*math.Pow(s.decayRate, steps), s.lowerBound)
+}
+
+// slopeAt returns the derivative of rawAt with respect to numerator.
+func (s *System) slopeAt(numerator, denominator float64) float64 {
+	if s.decayRate > 0 {
+		score := s.decay(s.decaySteps(numerator, denominator))
+		if score <= s.lowerBound {
+			return 0
+		}
+
+		stepSlope := float64(s.participantCount-1) / denominator
+		if s.reversed {
+			stepSlope = -stepSlope
+		}
+
+		return score * math.Log(s.decayRate) * stepSlope
+	}
+
+	if s.piecewise != nil {
+		child, childNumerator, childDenominator := s.segment(numerator, denominator)
+		scale := float64(s.participantCount-1) / denominator
+		if s.reversed {
+			scale = -scale
+		}
+
+		return child.slopeAt(childNumerator, childDenominator) * scale
+	}
+
+	linearSlope := 1 / denominator
+	if s.reversed {
+		numerator = denominator - numerator
+		linearSlope = -linearSlope
+	}
+
+	linear := numerator / denominator
+	return s.curveDerivative(s.warp(linear)) * s.warpDerivative(linear) * linearSlope
+}
+
+// ExponentMode selects how the exponent warps the linear alpha of each position, and so where the curve concentrates
+// its drop in score. Both modes are identical with an exp of 1.
+type ExponentMode int
+
+const (
+	// ExpBackLoad warps alpha as alpha^exp, the default. Alpha grows slowly at first, so the top positions stay close
+	// to scoreMax and most of the drop in score happens towards last place.
+	ExpBackLoad ExponentMode = iota
+	// ExpFrontLoad warps alpha as 1-(1-alpha)^exp, mirroring ExpBackLoad. Alpha grows quickly at first, so scores drop
+	// steeply after the top positions and flatten out towards last place.
+	ExpFrontLoad
+)
+
+// warp raises a linear alpha in [0, 1] to the power of exponent as selected by exponentMode, or applies ease if the
+// System has one.
+func (s *System) warp(alpha float64) float64 {
+	if s.ease != nil {
+		return s.ease(alpha)
+	}
+
+	if s.exponentMode == ExpFrontLoad {
+		return 1 - math.Pow(1-alpha, s.exponent)
+	}
+
+	return math.Pow(alpha, s.exponent)
+}
+
+// easeStep is the step used to estimate the derivative of ease with a central difference.
+const easeStep = 1e-6
+
+// warpDerivative is the derivative of warp with respect to alpha.
+func (s *System) warpDerivative(alpha float64) float64 {
+	if s.ease != nil {
+		lower, upper := math.Max(alpha-easeStep, 0), math.Min(alpha+easeStep, 1)
+		return (s.ease(upper) - s.ease(lower)) / (upper - lower)
+	}
+
+	if s.exponentMode == ExpFrontLoad {
+		return s.exponent * math.Pow(1-alpha, s.exponent-1)
+	}
+
+	return s.exponent * math.Pow(alpha, s.exponent-1)
+}
+
+// middle returns the midpoint of the score range. Each bound is halved before adding them, so that bounds near the
+// limits of float64 do not overflow to infinity.
+func (s *System) middle() float64 {
+	return (s.lowerBound / 2.0) + (s.upperBound / 2.0)
+}
+
+func (s *System) control() float64 {
+	middle := s.middle()
+	return ((1 - s.controlCoefficient) * middle) + (s.controlCoefficient * s.upperBound)
+}
+
+// control2 is the second control point of a cubic System, blended towards lowerBound rather than upperBound.
+func (s *System) control2() float64 {
+	middle := s.middle()
+	return ((1 - s.controlCoefficient2) * middle) + (s.controlCoefficient2 * s.lowerBound)
+}
+
+// curve evaluates the System's bezier curve, quadratic or cubic, at alpha. The control points lie within the score
+// range, so the curve does too, but its weighted terms can round past the range when they are summed. The score is
+// clamped back into it, since with bounds near the limits of float64 that rounding would overflow to infinity.
+func (s *System) curve(alpha float64) float64 {
+	var score float64
+	if s.cubic {
+		score = cubicBezier(s.upperBound, s.lowerBound, s.controlPoint, s.controlPoint2, alpha)
+	} else {
+		score = bezier(s.upperBound, s.lowerBound, s.controlPoint, alpha)
+	}
+
+	return min(max(score, s.lowerBound), s.upperBound)
+}
+
+// curveDerivative is the derivative of curve with respect to alpha.
+func (s *System) curveDerivative(alpha float64) float64 {
+	if s.cubic {
+		return cubicBezierDerivative(s.upperBound, s.lowerBound, s.controlPoint, s.controlPoint2, alpha)
+	}
+
+	return bezierDerivative(s.upperBound, s.lowerBound, s.controlPoint, alpha)
+}
+
+// adjust applies the System's output options, such as quantization, to a score computed from the curve.
+func (s *System) adjust(score float64) float64 {
+	if s.quantum > 0 {
+		score = math.Round(score/s.quantum) * s.quantum
+	}
+
+	if s.integerScores {
+		score = math.Round(score)
+	}
+
+	if s.scoreCap > 0 {
+		score = min(score, s.effectiveCap())
+	}
+
+	return score
+}
+
+// effectiveCap returns the scoreCap set by WithScoreCap, rounded down to an integer when the System has integer scores,
+// so that capping never produces a fractional score.
+func (s *System) effectiveCap() float64 {
+	if s.integerScores {
+		return math.Floor(s.scoreCap)
+	}
+
+	return s.scoreCap
+}
+
+// Score returns the computed Bezier score for any given position in a leaderboard.
+//
+// position must be at least 1, and at most the participantCount. A value of 1 means first place, and a value of
+// participantCount means last place.
+//
+// The position is first mapped linearly onto [0, 1], then raised to the power of exp, and the result is used as the
+// interpolation parameter of the bezier curve. An exp of 1 leaves the progression linear.
+//
+// See https://dresswithpockets.github.io/2025/10/14/scoring-system.html
+//
+// example:
+//
+//	participantCount := 500
+//	scoreMin         := 1000.0
+//	scoreMax         := 100000.0
+//	coeff            := 0.5
+//	exp              := 1.33
+//	system           := bezierscore.New(participantCount, scoreMin, scoreMax, coeff, exp)
+//
+//	firstPlace  := system.Score(1)
+//	secondPlace := system.Score(2)
+//	lastPlace   := system.Score(participantCount)
+func (s *System) Score(position uint) (score float64, ok bool) {
+	if position == 0 || position > s.participantCount {
+		if s.logger != nil {
+			s.logger.LogAttrs(
+				context.Background(),
+				slog.LevelDebug,
+				"bezierscore: invalid position",
+				slog.Uint64("position", uint64(position)),
+				slog.Uint64("participantCount", uint64(s.participantCount)),
+			)
+		}
+
+		return 0, false
+	}
+
+	return s.ScoreUnchecked(position), true
+}
+
+// ScoreUnchecked returns the computed Bezier score for position, like Score, but without checking that position is
+// valid. It is intended for hot loops whose positions have already been validated.
+//
+// The result is undefined if position is 0 or greater than participantCount.
+func (s *System) ScoreUnchecked(position uint) float64 {
+	return s.scoreAt(float64(position-1), float64(s.participantCount-1))
+}
+
+// ScoreAt returns the computed Bezier score for a fractional position, such as 3.5, without rounding it to a whole
+// position. Whole positions score exactly the same as Score, which makes ScoreAt useful for animating between ranks.
+//
+// position must be at least 1, and at most the participantCount.
+func (s *System) ScoreAt(position float64) (score float64, ok bool) {
+	if !(position >= 1 && position <= float64(s.participantCount)) {
+		return 0, false
+	}
+
+	return s.scoreAt(position-1, float64(s.participantCount-1)), true
+}
+
+// Percentile returns the computed Bezier score at a fraction p of the way along the leaderboard, without rounding to a
+// discrete position. A p of 1 is first place and a p of 0 is last place, so p of 1 scores scoreMax unless the System is
+// reversed.
+//
+// p must be between 0 and 1 inclusive.
+func (s *System) Percentile(p float64) (score float64, ok bool) {
+	if !(p >= 0 && p <= 1) {
+		return 0, false
+	}
+
+	return s.scoreAt(1-p, 1), true
+}
+
+// TierThresholds returns the score at each of percents along the leaderboard, as computed by Percentile, for deriving
+// the score boundaries of percentile-based tiers. Since a percent of 1 is first place, the boundary of the top 1% is at
+// 0.99.
+//
+// Each of percents must be between 0 and 1 inclusive, otherwise a *ValidationError wrapping PercentOutOfRangeErr is
+// returned.
+func (s *System) TierThresholds(percents []float64) ([]float64, error) {
+	thresholds := make([]float64, len(percents))
+	for idx, percent := range percents {
+		score, ok := s.Percentile(percent)
+		if !ok {
+			return nil, &ValidationError{fmt.Sprintf("percents[%d]", idx), percent, PercentOutOfRangeErr}
+		}
+
+		thresholds[idx] = score
+	}
+
+	return thresholds, nil
+}
+
+// Slope returns the derivative of the score curve with respect to position, at position. Output options like
+// quantization are ignored, so this is the slope of the underlying curve. It is computed analytically using the chain
+// rule:
+//
+//	B'(a)  = 2(1-a)(control-scoreMax) + 2a(scoreMin-control)
+//	       (or the cubic equivalent, for Systems constructed with NewCubic)
+//	a(r)   = r^exp, so a'(r) = exp * r^(exp-1)
+//	       (or 1-(1-r)^exp, so a'(r) = exp * (1-r)^(exp-1), with ExpFrontLoad)
+//	r(p)   = (p-1) / (participantCount-1), so r'(p) = 1 / (participantCount-1)
+//	slope  = B'(a(r(p))) * a'(r(p)) * r'(p)
+//
+// When reversed, r(p) = (participantCount-p) / (participantCount-1) and r'(p) is negated. For Systems constructed with
+// NewWithEasing, a(r) is ease and a'(r) is estimated with a central difference instead. Systems constructed with
+// NewPiecewise use the slope of the System scoring position, scaled to the length of its segment.
+//
+// position must be at least 1, and at most the participantCount, just like Score.
+func (s *System) Slope(position uint) (slope float64, ok bool) {
+	if position == 0 || position > s.participantCount {
+		return 0, false
+	}
+
+	return s.slopeAt(float64(position-1), float64(s.participantCount-1)), true
+}
+
+// ScoreDelta returns how many more points position is worth than the position after it, i.e.
+// Score(position) - Score(position+1). The delta is negative when the System is reversed.
+//
+// position must be at least 1, and less than participantCount, since last place has no position after it.
+func (s *System) ScoreDelta(position uint) (delta float64, ok bool) {
+	if position == 0 || position >= s.participantCount {
+		return 0, false
+	}
+
+	current, _ := s.Score(position)
+	next, _ := s.Score(position + 1)
+	return current - next, true
+}
+
+// ScoreTie returns the score awarded to each of count participants tied at fromPosition: the average of the scores for
+// positions fromPosition through fromPosition+count-1, which the tied participants collectively occupy.
+//
+// fromPosition must be at least 1, count must be at least 1, and the tied positions must not extend past
+// participantCount.
+func (s *System) ScoreTie(fromPosition, count uint) (score float64, ok bool) {
+	if fromPosition == 0 || count == 0 || fromPosition > s.participantCount || count > s.participantCount-fromPosition+1 {
+		return 0, false
+	}
+
+	total := 0.0
+	for position := fromPosition; position < fromPosition+count; position++ {
+		current, _ := s.Score(position)
+		total += current
+	}
+
+	return total / float64(count), true
+}
+
+// MeanInRange returns the average of the scores for positions start through end inclusive, such as the average score
+// of the top quartile. It is the same average ScoreTie computes, expressed as a range of positions rather than a count.
+//
+// start must be at least 1, end must be at least start, and end must be at most participantCount.
+func (s *System) MeanInRange(start, end uint) (mean float64, ok bool) {
+	if start == 0 || end < start || end > s.participantCount {
+		return 0, false
+	}
+
+	return s.ScoreTie(start, end-start+1)
+}
+
+// ScoreInt returns the computed Bezier score for any given position in a leaderboard, rounded half away from zero to
+// the nearest integer.
+//
+// position must be at least 1, and at most the participantCount, just like Score.
+func (s *System) ScoreInt(position uint) (score int64, ok bool) {
+	return s.ScoreIntMode(position, RoundNearest)
+}
+
+// RoundMode selects how ScoreIntMode rounds a score to an integer.
+type RoundMode int
+
+const (
+	// RoundNearest rounds half away from zero to the nearest integer.
+	RoundNearest RoundMode = iota
+	// RoundFloor rounds down, towards negative infinity, to avoid over-awarding points.
+	RoundFloor
+	// RoundCeil rounds up, towards positive infinity.
+	RoundCeil
+	// RoundTowardZero discards the fractional part.
+	RoundTowardZero
+)
+
+// ScoreIntMode returns the computed Bezier score for any given position in a leaderboard, rounded to an integer
+// according to mode.
+//
+// position must be at least 1, and at most the participantCount, just like Score. ok is also false for an unknown
+// mode, and when the rounded score does not fit in an int64, as it may not for score ranges beyond 2^63.
+func (s *System) ScoreIntMode(position uint, mode RoundMode) (score int64, ok bool) {
+	value, ok := s.Score(position)
+	if !ok {
+		return 0, false
+	}
+
+	switch mode {
+	case RoundNearest:
+		value = math.Round(value)
+	case RoundFloor:
+		value = math.Floor(value)
+	case RoundCeil:
+		value = math.Ceil(value)
+	case RoundTowardZero:
+		value = math.Trunc(value)
+	default:
+		return 0, false
+	}
+
+	// float64(math.MaxInt64) rounds up to 2^63, which is itself out of range.
+	if !(value >= math.MinInt64 && value < math.MaxInt64) {
+		return 0, false
+	}
+
+	return int64(value), true
+}
+
+// ScoreNormalized returns the computed Bezier score for position, scaled so that scoreMin maps to 0 and scoreMax maps
+// to 1. First place is therefore 1 and last place is 0, or the other way around when reversed.
+//
+// position must be at least 1, and at most the participantCount, just like Score.
+func (s *System) ScoreNormalized(position uint) (n float64, ok bool) {
+	score, ok := s.Score(position)
+	if !ok {
+		return 0, false
+	}
+
+	return (score - s.lowerBound) / (s.upperBound - s.lowerBound), true
+}
+
+// Rescale maps score linearly from the System's [scoreMin, scoreMax] onto [newMin, newMax], for carrying scores over
+// to a leaderboard with a different range. scoreMin maps to newMin and scoreMax maps to newMax.
+//
+// score is not clamped: scores outside [scoreMin, scoreMax] are extrapolated along the same line, so callers that need
+// the result within [newMin, newMax] should clamp it themselves.
+func (s *System) Rescale(score, newMin, newMax float64) float64 {
+	fraction := (score - s.lowerBound) / (s.upperBound - s.lowerBound)
+	return newMin + (fraction * (newMax - newMin))
+}
+
+// ScoreRatio returns the computed Bezier score for position as a fraction of first place's score, e.g. 0.6 when
+// position earns 60% of the top score.
+//
+// position must be at least 1, and at most the participantCount, just like Score. ok is also false when first place
+// scores 0, as it can for Systems constructed with NewUnbounded or quantized with a step larger than scoreMax.
+func (s *System) ScoreRatio(position uint) (ratio float64, ok bool) {
+	score, ok := s.Score(position)
+	if !ok {
+		return 0, false
+	}
+
+	first, _ := s.Score(1)
+	if first == 0 {
+		return 0, false
+	}
+
+	return score / first, true
+}
+
+// ScoreClamped returns the computed Bezier score for position, clamped into [lo, hi]. This is useful for displaying
+// scores in a narrower range than [scoreMin, scoreMax].
+//
+// position must be at least 1, and at most the participantCount, just like Score. ok is also false if lo is greater
+// than hi, or either is NaN.
+func (s *System) ScoreClamped(position uint, lo, hi float64) (score float64, ok bool) {
+	if !(lo <= hi) {
+		return 0, false
+	}
+
+	score, ok = s.Score(position)
+	if !ok {
+		return 0, false
+	}
+
+	return min(max(score, lo), hi), true
+}
+
+// ScoreSigFig returns the computed Bezier score for position, rounded to figs significant figures, e.g. 12345.6 becomes
+// 12300 and 1.23456 becomes 1.23 with figs of 3.
+//
+// position must be at least 1, and at most the participantCount, just like Score. ok is also false if figs is less
+// than 1.
+func (s *System) ScoreSigFig(position uint, figs int) (score float64, ok bool) {
+	if figs < 1 {
+		return 0, false
+	}
+
+	score, ok = s.Score(position)
+	if !ok {
+		return 0, false
+	}
+
+	// formatting with 'g' rounds to figs significant digits regardless of magnitude, without the error that scaling by
+	// a power of 10 would introduce.
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(score, 'g', figs, 64), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return rounded, true
 }
 
-func New(participantCount uint, scoreMin, scoreMax, coeff, exp float64) (*System, error) {
-	if participantCount < 2 {
-		return nil, ParticipantCountOutOfRangeErr
+// Rank returns the position whose computed score is closest to score. It is the inverse of Score.
+//
+// score must be between the scores of first and last place inclusive. When score falls exactly between two positions,
+// the better (lower) position is returned.
+//
+// Rank binary searches over positions rather than solving the curve analytically, relying on scores never increasing
+// from one position to the next (or never decreasing, when reversed). Overrides from WithOverride can break that order,
+// so Systems with overrides scan every position instead, and score need only be between the lowest and highest scores
+// awarded.
+func (s *System) Rank(score float64) (position uint, ok bool) {
+	if len(s.overrides) > 0 {
+		return rankScan(s.participantCount, s.ScoreUnchecked, score)
 	}
 
-	if scoreMin < 1 {
-		return nil, ScoreMinOutOfRangeErr
+	first, _ := s.Score(1)
+	last, _ := s.Score(s.participantCount)
+	if !(score >= min(first, last) && score <= max(first, last)) {
+		return 0, false
 	}
 
-	if scoreMax <= scoreMin {
-		return nil, ScoreMaxOutOfRangeErr
-	}
+	// find the first position whose score has reached the given score. last place is at one end of the range checked
+	// above, so this is always a valid index.
+	idx := sort.Search(int(s.participantCount), func(i int) bool {
+		candidate, _ := s.Score(uint(i) + 1)
+		if s.reversed {
+			return candidate >= score
+		}
+
+		return candidate <= score
+	})
 
-	if coeff < 0 || coeff > 1 {
-		return nil, CoefficientOutOfRangeErr
+	position = uint(idx) + 1
+	if position == 1 {
+		return position, true
 	}
 
-	if exp < 1 {
-		return nil, ExponentOutOfRangeErr
+	current, _ := s.Score(position)
+	previous, _ := s.Score(position - 1)
+	if math.Abs(previous-score) <= math.Abs(current-score) {
+		return position - 1, true
 	}
 
-	return &System{
-		participantCount:   participantCount,
-		upperBound:         scoreMin,
-		lowerBound:         scoreMax,
-		controlCoefficient: coeff,
-		exponent:           exp,
-	}, nil
+	return position, true
 }
 
-func (s *System) alpha(position uint) float64 {
-	numerator := 1.0 - float64(position-1)
-	denominator := float64(s.participantCount - 1)
-	return 1.0 - (numerator / denominator)
-}
+// rankScan returns the position out of participantCount whose score is closest to score, by scanning every position
+// rather than relying on their order. Ties go to the better (lower) position, as they do for Rank. ok is false unless
+// score is between the lowest and highest scores.
+func rankScan(participantCount uint, scoreOf func(position uint) float64, score float64) (position uint, ok bool) {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	closest := math.Inf(1)
+	for current := uint(1); current <= participantCount; current++ {
+		candidate := scoreOf(current)
+		lowest = min(lowest, candidate)
+		highest = max(highest, candidate)
+		if distance := math.Abs(candidate - score); distance < closest {
+			position, closest = current, distance
+		}
+	}
 
-func (s *System) control() float64 {
-	middle := (s.lowerBound + s.upperBound) / 2.0
-	return ((1 - s.controlCoefficient) * middle) + (s.controlCoefficient * s.lowerBound)
+	if !(score >= lowest && score <= highest) {
+		return 0, false
+	}
+
+	return position, true
 }
 
-// Score returns the computed Bezier score for any given position in a leaderboard.
-//
-// position must be at least 1, and at most the participantCount. A value of 1 means first place, and a value of
-// participantCount means last place.
-//
-// See https://dresswithpockets.github.io/2025/10/14/scoring-system.html
-//
-// example:
+// LastPositionAbove returns the highest (worst) position whose score is at least threshold, which is useful for finding
+// the cutoff of a reward tier. ok is false when no position reaches threshold.
 //
-//	participantCount := 500
-//	scoreMin         := 1000.0
-//	scoreMax         := 100000.0
-//	coeff            := 0.5
-//	exp              := 1.33
-//	system           := bezierscore.New(participantCount, scoreMin, scoreMax, coeff, exp)
-//
-//	firstPlace  := system.Score(1)
-//	secondPlace := system.Score(2)
-//	lastPlace   := system.Score(participantCount)
-func (s *System) Score(position uint) (score float64, ok bool) {
-	if position == 0 || position > s.participantCount {
+// Since scores never increase from one position to the next, this is a binary search. When reversed, scores never
+// decrease, so this is participantCount whenever last place reaches threshold. Overrides from WithOverride can break
+// that order, so Systems with overrides scan every position from last place up instead.
+func (s *System) LastPositionAbove(threshold float64) (position uint, ok bool) {
+	if len(s.overrides) > 0 {
+		for position = s.participantCount; position > 0; position-- {
+			if s.ScoreUnchecked(position) >= threshold {
+				return position, true
+			}
+		}
+
+		return 0, false
+	}
+
+	if s.reversed {
+		last, _ := s.Score(s.participantCount)
+		if last < threshold {
+			return 0, false
+		}
+
+		return s.participantCount, true
+	}
+
+	// find the first position whose score falls below threshold; the position before it is the last one above.
+	idx := sort.Search(int(s.participantCount), func(i int) bool {
+		score, _ := s.Score(uint(i) + 1)
+		return score < threshold
+	})
+
+	if idx == 0 {
 		return 0, false
 	}
 
-	alpha := s.alpha(position)
-	score = bezier(s.lowerBound, s.upperBound, s.control(), alpha)
-	return score, true
+	return uint(idx), true
 }
 
 // ScoreAll computes the Bezier score for every index in buf.
 //
 // len(buf) must equal participantCount.
 //
+// ScoreAll never allocates, so buf can be reused across calls in long-running services without creating garbage. The
+// control points are computed once when the System is constructed, and nothing in the scoring path escapes to the
+// heap. The one exception is an easing function passed to NewWithEasing, which is the caller's responsibility.
+//
 // example:
 //
 //	participantCount := 500
@@ -116,12 +1542,581 @@ func (s *System) ScoreAll(buf []float64) (ok bool) {
 	}
 
 	for idx := uint(0); idx < uint(len(buf)); idx++ {
+		buf[idx] = s.ScoreUnchecked(idx + 1)
+	}
+
+	return true
+}
+
+// ScoreAllErr computes the Bezier score for every index in buf, like ScoreAll, but reports a mismatched buf with an
+// error naming both lengths, for callers that want to log the specifics.
+//
+// len(buf) must equal participantCount, otherwise the returned error wraps BufferLengthErr.
+func (s *System) ScoreAllErr(buf []float64) error {
+	if !s.ScoreAll(buf) {
+		return fmt.Errorf(
+			"buffer length %d does not match participant count %d: %w",
+			len(buf),
+			s.participantCount,
+			BufferLengthErr,
+		)
+	}
+
+	return nil
+}
+
+// ScoreAllFloat32 computes the Bezier score for every index in buf, like ScoreAll, converting each score to float32 as
+// it is stored. The curve math is still performed in float64; see System32 for float32 math.
+//
+// len(buf) must equal participantCount.
+func (s *System) ScoreAllFloat32(buf []float32) (ok bool) {
+	if uint(len(buf)) != s.participantCount {
+		return false
+	}
+
+	for idx := uint(0); idx < uint(len(buf)); idx++ {
+		score, _ := s.Score(idx + 1)
+		buf[idx] = float32(score)
+	}
+
+	return true
+}
+
+// ScoreRange computes the Bezier score for positions start through end inclusive into buf, so that buf[0] holds the
+// score for start.
+//
+// start and end must satisfy 1 <= start <= end <= participantCount, and len(buf) must equal end-start+1.
+func (s *System) ScoreRange(start, end uint, buf []float64) (ok bool) {
+	if start == 0 || start > end || end > s.participantCount {
+		return false
+	}
+
+	if uint(len(buf)) != end-start+1 {
+		return false
+	}
+
+	for idx := range buf {
+		buf[idx], _ = s.Score(start + uint(idx))
+	}
+
+	return true
+}
+
+// ScoreSet computes the Bezier score for each of positions into buf, so that buf[i] holds the score for positions[i].
+// This is useful for scoring a sparse set of positions, such as 1, 10 and 100, without scoring the whole leaderboard.
+//
+// len(buf) must equal len(positions), and every position must be at least 1 and at most participantCount. Positions
+// are checked before any score is computed, so buf is left untouched when ok is false.
+func (s *System) ScoreSet(positions []uint, buf []float64) (ok bool) {
+	if len(buf) != len(positions) {
+		return false
+	}
+
+	for _, position := range positions {
+		if position == 0 || position > s.participantCount {
+			return false
+		}
+	}
+
+	for idx, position := range positions {
+		buf[idx] = s.ScoreUnchecked(position)
+	}
+
+	return true
+}
+
+// TopN returns the scores for positions 1 through n, ordered from first place down.
+//
+// n must be between 1 and participantCount inclusive, otherwise a *ValidationError wrapping TopNOutOfRangeErr is
+// returned.
+func (s *System) TopN(n uint) ([]float64, error) {
+	if n == 0 || n > s.participantCount {
+		return nil, &ValidationError{"n", float64(n), TopNOutOfRangeErr}
+	}
+
+	scores := make([]float64, n)
+	s.ScoreRange(1, n, scores)
+	return scores, nil
+}
+
+// ScoreAllN computes the Bezier score for every position into the first participantCount entries of buf, and returns
+// the number of entries written. Entries beyond participantCount are left untouched.
+//
+// len(buf) must be at least participantCount.
+func (s *System) ScoreAllN(buf []float64) (n int, ok bool) {
+	if uint(len(buf)) < s.participantCount {
+		return 0, false
+	}
+
+	n = int(s.participantCount)
+	s.ScoreAll(buf[:n])
+	return n, true
+}
+
+// ScoreAllParallel computes the Bezier score for every index in buf, splitting the work across workers goroutines that
+// each fill a disjoint segment of buf. The results are identical to ScoreAll.
+//
+// len(buf) must equal participantCount. If workers is at most 0, runtime.GOMAXPROCS(0) workers are used.
+func (s *System) ScoreAllParallel(buf []float64, workers int) (ok bool) {
+	if uint(len(buf)) != s.participantCount {
+		return false
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	workers = min(workers, len(buf))
+	segment := (len(buf) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(buf); start += segment {
+		end := min(start+segment, len(buf))
+		wg.Go(func() {
+			for idx := start; idx < end; idx++ {
+				buf[idx], _ = s.Score(uint(idx) + 1)
+			}
+		})
+	}
+
+	wg.Wait()
+	return true
+}
+
+// contextCheckInterval is how many scores ScoreAllContext computes between checks of its context.
+const contextCheckInterval = 4096
+
+// ScoreAllContext computes the Bezier score for every index in buf, like ScoreAll, checking ctx every few thousand
+// positions.
+//
+// len(buf) must equal participantCount, otherwise BufferLengthErr is returned. If ctx is done, ScoreAllContext returns
+// ctx.Err() early; any prefix of buf may have been written by then, and the rest is left untouched.
+func (s *System) ScoreAllContext(ctx context.Context, buf []float64) error {
+	if uint(len(buf)) != s.participantCount {
+		return BufferLengthErr
+	}
+
+	for idx := uint(0); idx < uint(len(buf)); idx++ {
+		if idx%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
 		buf[idx], _ = s.Score(idx + 1)
 	}
 
+	return nil
+}
+
+// ScoreAllFunc computes the Bezier score for every position, calling set once per position from first place to last
+// place. This lets callers store scores directly into their own structures, such as a field of a slice of players.
+//
+// n must equal participantCount, as a check that the caller's structure is sized for the whole leaderboard.
+//
+// example:
+//
+//	_ = system.ScoreAllFunc(uint(len(players)), func(position uint, score float64) {
+//		players[position-1].Score = score
+//	})
+func (s *System) ScoreAllFunc(n uint, set func(position uint, score float64)) (ok bool) {
+	if n != s.participantCount {
+		return false
+	}
+
+	for position := uint(1); position <= n; position++ {
+		set(position, s.ScoreUnchecked(position))
+	}
+
+	return true
+}
+
+// ScoreSlice returns a newly allocated slice holding the Bezier score for every position, from first place to last
+// place. Every call returns a fresh copy owned by the caller, so it can be handed out and modified freely.
+func (s *System) ScoreSlice() []float64 {
+	scores := make([]float64, s.participantCount)
+	s.ScoreAll(scores)
+	return scores
+}
+
+// AppendScores appends the Bezier score for every position to dst, from first place to last place, and returns the
+// extended slice. Existing contents of dst are preserved, and its capacity is reused when sufficient.
+func (s *System) AppendScores(dst []float64) []float64 {
+	start := len(dst)
+	dst = slices.Grow(dst, int(s.participantCount))[:start+int(s.participantCount)]
+	s.ScoreAll(dst[start:])
+	return dst
+}
+
+// ScoreMap computes the Bezier score for every position, keyed by position.
+//
+// The returned map has exactly participantCount entries, for positions 1 through participantCount.
+func (s *System) ScoreMap() map[uint]float64 {
+	scores := make(map[uint]float64, s.participantCount)
+	for position := uint(1); position <= s.participantCount; position++ {
+		scores[position], _ = s.Score(position)
+	}
+
+	return scores
+}
+
+// All returns an iterator over every position and its score, from first place to last place. Scores are computed
+// lazily as the iterator advances.
+//
+// example:
+//
+//	for position, score := range system.All() {
+//		fmt.Println(position, score)
+//	}
+func (s *System) All() iter.Seq2[uint, float64] {
+	return func(yield func(uint, float64) bool) {
+		for position := uint(1); position <= s.participantCount; position++ {
+			score, _ := s.Score(position)
+			if !yield(position, score) {
+				return
+			}
+		}
+	}
+}
+
+// Entry pairs a leaderboard position with its score.
+type Entry struct {
+	Position uint
+	Score    float64
+}
+
+// Entries computes the Bezier score for every position, returning one Entry per position in ascending position order.
+func (s *System) Entries() []Entry {
+	entries := make([]Entry, s.participantCount)
+	for idx := range entries {
+		position := uint(idx) + 1
+		score, _ := s.Score(position)
+		entries[idx] = Entry{Position: position, Score: score}
+	}
+
+	return entries
+}
+
+// TotalScore returns the sum of the scores awarded to every position on a full leaderboard.
+func (s *System) TotalScore() float64 {
+	total := 0.0
+	for position := uint(1); position <= s.participantCount; position++ {
+		score, _ := s.Score(position)
+		total += score
+	}
+
+	return total
+}
+
+// CumulativeScore returns the sum of the scores for positions 1 through position inclusive, such as the points
+// earned by the top 10 combined. It scores every position up to position, so callers summing many prefixes should use
+// the CumulativeScore of a Table from Precompute instead.
+//
+// position must be at least 1, and at most the participantCount, just like Score.
+func (s *System) CumulativeScore(position uint) (sum float64, ok bool) {
+	if position == 0 || position > s.participantCount {
+		return 0, false
+	}
+
+	for current := uint(1); current <= position; current++ {
+		sum += s.ScoreUnchecked(current)
+	}
+
+	return sum, true
+}
+
+// MaxScore returns the highest score awarded to any position, found by scanning every position. This is usually
+// scoreMax, but callers normalizing scores should prefer it over assuming so.
+func (s *System) MaxScore() float64 {
+	highest := math.Inf(-1)
+	for position := uint(1); position <= s.participantCount; position++ {
+		score, _ := s.Score(position)
+		highest = math.Max(highest, score)
+	}
+
+	return highest
+}
+
+// MinScore returns the lowest score awarded to any position, found by scanning every position. This is usually
+// scoreMin, but callers normalizing scores should prefer it over assuming so.
+func (s *System) MinScore() float64 {
+	lowest := math.Inf(1)
+	for position := uint(1); position <= s.participantCount; position++ {
+		score, _ := s.Score(position)
+		lowest = math.Min(lowest, score)
+	}
+
+	return lowest
+}
+
+// Spread returns how many points separate the highest and lowest scores on the board, MaxScore() - MinScore(). This
+// is the effective dynamic range of the curve, which is scoreMax - scoreMin unless output options narrow it.
+func (s *System) Spread() float64 {
+	return s.MaxScore() - s.MinScore()
+}
+
+// Stats summarizes the scores awarded to every position on a full leaderboard.
+type Stats struct {
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+
+	// StdDev is the population standard deviation, since the scores of every position are included.
+	StdDev float64
+}
+
+// Stats computes summary statistics over the scores of every position. Min, Max, Mean and StdDev are accumulated in a
+// single pass, using Welford's algorithm for the mean and variance. Median takes the middle score, or the average of
+// the two middle scores, after sorting a copy of every score.
+func (s *System) Stats() Stats {
+	scores := s.ScoreSlice()
+	stats := Stats{Min: math.Inf(1), Max: math.Inf(-1)}
+
+	sumOfSquares := 0.0
+	for idx, score := range scores {
+		stats.Min = min(stats.Min, score)
+		stats.Max = max(stats.Max, score)
+
+		delta := score - stats.Mean
+		stats.Mean += delta / float64(idx+1)
+		sumOfSquares += delta * (score - stats.Mean)
+	}
+
+	stats.StdDev = math.Sqrt(sumOfSquares / float64(len(scores)))
+
+	slices.Sort(scores)
+	middle := len(scores) / 2
+	if len(scores)%2 == 0 {
+		stats.Median = (scores[middle-1] / 2) + (scores[middle] / 2)
+	} else {
+		stats.Median = scores[middle]
+	}
+
+	return stats
+}
+
+// SamplePosition maps r, in [0, 1), onto a position with probability proportional to that position's share of
+// TotalScore. Passing uniformly random values of r therefore samples positions weighted by score, with higher-scoring
+// positions proportionally more likely.
+//
+// The positions' cumulative scores are laid end to end in position order, so r of 0 always yields first place. ok is
+// false if r is out of range, or if any score is negative or the total is not positive, since the scores then do not
+// form a distribution.
+func (s *System) SamplePosition(r float64) (position uint, ok bool) {
+	if !(r >= 0 && r < 1) {
+		return 0, false
+	}
+
+	cumulative := make([]float64, s.participantCount)
+	total := 0.0
+	for idx := range cumulative {
+		score := s.ScoreUnchecked(uint(idx) + 1)
+		if score < 0 {
+			return 0, false
+		}
+
+		total += score
+		cumulative[idx] = total
+	}
+
+	if total <= 0 {
+		return 0, false
+	}
+
+	// find the first position whose cumulative share exceeds r. r is less than 1, so this is always a valid index.
+	target := r * total
+	idx := sort.Search(len(cumulative), func(i int) bool {
+		return cumulative[i] > target
+	})
+
+	return uint(min(idx, len(cumulative)-1)) + 1, true
+}
+
+// Histogram counts how many positions score within each of bins equal-width bins spanning MinScore to MaxScore. A
+// score landing exactly on the boundary between two bins is counted in the lower bin.
+//
+// bins must be at least 1, otherwise a *ValidationError wrapping BinCountOutOfRangeErr is returned.
+func (s *System) Histogram(bins int) ([]uint, error) {
+	if bins < 1 {
+		return nil, &ValidationError{"bins", float64(bins), BinCountOutOfRangeErr}
+	}
+
+	lowest, highest := s.MinScore(), s.MaxScore()
+	width := (highest - lowest) / float64(bins)
+	counts := make([]uint, bins)
+	for position := uint(1); position <= s.participantCount; position++ {
+		score, _ := s.Score(position)
+
+		bin := 0
+		if width > 0 {
+			bin = int(math.Ceil((score-lowest)/width)) - 1
+		}
+
+		counts[min(max(bin, 0), bins-1)]++
+	}
+
+	return counts, nil
+}
+
+// Collisions returns the groups of positions that are awarded exactly the same score, which happens when output
+// options like WithQuantum or WithIntegerScores round neighbouring positions onto the same value, or when
+// WithFloorBelow flattens the bottom of the leaderboard. Each group lists its positions in ascending order, and the
+// groups are ordered by their first position. Positions with a unique score are omitted, so the result is empty when
+// every score is distinct.
+func (s *System) Collisions() [][]uint {
+	groups := make(map[float64][]uint)
+	var scores []float64
+	for position := uint(1); position <= s.participantCount; position++ {
+		score := s.ScoreUnchecked(position)
+		if _, found := groups[score]; !found {
+			scores = append(scores, score)
+		}
+
+		groups[score] = append(groups[score], position)
+	}
+
+	var collisions [][]uint
+	for _, score := range scores {
+		if group := groups[score]; len(group) > 1 {
+			collisions = append(collisions, group)
+		}
+	}
+
+	return collisions
+}
+
+// curvatureSamples is how many interior points Curvature compares against the straight line between the endpoints.
+const curvatureSamples = 64
+
+// Curvature classifies the shape of the score curve by comparing it to the straight line between first and last
+// place. It returns:
+//
+//	+1 for a convex, front-loaded curve, lying below the line, whose scores drop quickly after the top positions
+//	-1 for a concave curve, lying above the line, whose scores stay high before dropping towards the bottom
+//	 0 for a linear curve, or one that crosses the line, like a cubic S-curve
+//
+// For quadratic Systems this is decided by whether the control point lies above or below the middle of the score
+// range, and by exp, which bows the curve upwards whenever it is more than 1. A coeff of 0 with an exp of 1 is linear.
+// Output options like quantization are ignored.
+func (s *System) Curvature() int {
+	first, last := s.rawAt(0, 1), s.rawAt(1, 1)
+	tolerance := equalEpsilon * math.Abs(last-first)
+
+	above, below := false, false
+	for idx := 1; idx < curvatureSamples; idx++ {
+		fraction := float64(idx) / curvatureSamples
+		line := first + ((last - first) * fraction)
+		score := s.rawAt(fraction, 1)
+
+		switch {
+		case score > line+tolerance:
+			above = true
+		case score < line-tolerance:
+			below = true
+		}
+	}
+
+	switch {
+	case below && !above:
+		return 1
+	case above && !below:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// InflectionPosition returns the position nearest the first point where the score curve changes concavity, such as the
+// middle of the S-curve of a cubic System. ok is false if the curve has no inflection between first and last place,
+// which is the case for every quadratic System with an exp of 1.
+//
+// The inflection is found by scanning the Slope of every position for the point where it stops steepening and starts
+// flattening, or vice versa. Output options like quantization are ignored, as they are for Slope.
+func (s *System) InflectionPosition() (position uint, ok bool) {
+	denominator := float64(s.participantCount - 1)
+
+	// slopes are differenced, so ignore changes too small to be anything but rounding error.
+	tolerance := equalEpsilon * math.Abs(s.upperBound-s.lowerBound) / denominator
+
+	previousSlope := s.slopeAt(0, denominator)
+	previousSign := 0
+	for numerator := uint(1); numerator < s.participantCount; numerator++ {
+		slope := s.slopeAt(float64(numerator), denominator)
+		change := slope - previousSlope
+		previousSlope = slope
+
+		sign := 0
+		switch {
+		case change > tolerance:
+			sign = 1
+		case change < -tolerance:
+			sign = -1
+		default:
+			continue
+		}
+
+		if previousSign != 0 && sign != previousSign {
+			return numerator, true
+		}
+
+		previousSign = sign
+	}
+
+	return 0, false
+}
+
+// IsMonotonic reports whether scores never increase from one position to the next, or never decrease when reversed,
+// by scanning every position. The curves of New always are, but an easing function passed to NewWithEasing that is
+// not itself monotonic, or a piecewise System whose segments overlap, can produce a leaderboard where a worse position
+// outscores a better one.
+func (s *System) IsMonotonic() bool {
+	previous := s.ScoreUnchecked(1)
+	for position := uint(2); position <= s.participantCount; position++ {
+		score := s.ScoreUnchecked(position)
+		if (!s.reversed && score > previous) || (s.reversed && score < previous) {
+			return false
+		}
+
+		previous = score
+	}
+
 	return true
 }
 
+// Matches reports whether the System's score for every position is within tolerance of reference, where reference[0]
+// holds the expected score for first place. This is useful for checking a System against a known-good set of scores.
+//
+// mismatch is the index of the first score outside tolerance, or -1 if every score matches. len(reference) must equal
+// participantCount, otherwise Matches returns false and -1.
+func (s *System) Matches(reference []float64, tolerance float64) (ok bool, mismatch int) {
+	if uint(len(reference)) != s.participantCount {
+		return false, -1
+	}
+
+	for idx, expected := range reference {
+		score := s.ScoreUnchecked(uint(idx) + 1)
+		if !(math.Abs(score-expected) <= tolerance) {
+			return false, idx
+		}
+	}
+
+	return true, -1
+}
+
+// Compare returns the scores a and b award to position, and their difference aScore - bScore.
+//
+// position must be valid for both a and b, which may have different participant counts.
+func Compare(a, b *System, position uint) (aScore, bScore, diff float64, ok bool) {
+	aScore, aOk := a.Score(position)
+	bScore, bOk := b.Score(position)
+	if !aOk || !bOk {
+		return 0, 0, 0, false
+	}
+
+	return aScore, bScore, aScore - bScore, true
+}
+
 /*
 
 Copyright 2026 dresswithpockets
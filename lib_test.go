@@ -0,0 +1,2455 @@
+package bezierscore
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newSystem constructs a System with New, failing the test if the parameters are invalid.
+func newSystem(t testing.TB, participantCount uint, scoreMin, scoreMax, coeff, exp float64) *System {
+	t.Helper()
+
+	system, err := New(participantCount, scoreMin, scoreMax, coeff, exp)
+	if err != nil {
+		t.Fatalf("New(%d, %g, %g, %g, %g): %v", participantCount, scoreMin, scoreMax, coeff, exp, err)
+	}
+
+	return system
+}
+
+func TestScoreExponentChangesCurve(t *testing.T) {
+	linear := newSystem(t, 10, 1000, 100000, 0.5, 1)
+	warped := newSystem(t, 10, 1000, 100000, 0.5, 3)
+
+	for position := uint(1); position <= 10; position++ {
+		linearScore, _ := linear.Score(position)
+		warpedScore, _ := warped.Score(position)
+
+		switch position {
+		case 1, 10:
+			if warpedScore != linearScore {
+				t.Errorf("Score(%d) = %g with exp 3, want %g with exp 1", position, warpedScore, linearScore)
+			}
+		default:
+			// raising alpha to a higher power keeps it smaller, holding interior scores closer to scoreMax.
+			if !(warpedScore > linearScore) {
+				t.Errorf("Score(%d) = %g with exp 3, want more than %g with exp 1", position, warpedScore, linearScore)
+			}
+		}
+	}
+}
+
+func TestScoreExponentWarpsAlpha(t *testing.T) {
+	for _, exp := range []float64{1, 1.33, 2, 5} {
+		system := newSystem(t, 5, 1000, 100000, 0.5, exp)
+		for position := uint(1); position <= 5; position++ {
+			linear := float64(position-1) / 4
+			want := bezier(100000, 1000, system.ControlPoint(), math.Pow(linear, exp))
+			if got, _ := system.Score(position); math.Abs(got-want) > 1e-9*want {
+				t.Errorf("exp %g: Score(%d) = %g, want %g", exp, position, got, want)
+			}
+		}
+	}
+}
+
+func TestScoreEndpoints(t *testing.T) {
+	for _, coeff := range []float64{0, 0.5, 1} {
+		for _, exp := range []float64{1, 1.33, 4} {
+			system := newSystem(t, 500, 1000, 100000, coeff, exp)
+			if first, _ := system.Score(1); first != 100000 {
+				t.Errorf("coeff %g, exp %g: Score(1) = %g, want scoreMax 100000", coeff, exp, first)
+			}
+
+			if last, _ := system.Score(500); last != 1000 {
+				t.Errorf("coeff %g, exp %g: Score(500) = %g, want scoreMin 1000", coeff, exp, last)
+			}
+		}
+	}
+}
+
+func TestScoreInvalidPosition(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for _, position := range []uint{0, 501} {
+		if score, ok := system.Score(position); ok {
+			t.Errorf("Score(%d) = %g, true, want false", position, score)
+		}
+	}
+}
+
+func TestAlpha(t *testing.T) {
+	tests := []struct {
+		name             string
+		participantCount uint
+		position         uint
+		want             float64
+	}{
+		{"first of two", 2, 1, 0},
+		{"last of two", 2, 2, 1},
+		{"first of five", 5, 1, 0},
+		{"second of five", 5, 2, 0.25},
+		{"middle of five", 5, 3, 0.5},
+		{"fourth of five", 5, 4, 0.75},
+		{"last of five", 5, 5, 1},
+		{"first of 500", 500, 1, 0},
+		{"last of 500", 500, 500, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			system := newSystem(t, test.participantCount, 1000, 100000, 0.5, 1)
+			if got, ok := system.Alpha(test.position); !ok || got != test.want {
+				t.Errorf("Alpha(%d) = %g, %t, want %g, true", test.position, got, ok, test.want)
+			}
+		})
+	}
+}
+
+func TestAlphaMonotonic(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	previous := -1.0
+	for position := uint(1); position <= 500; position++ {
+		alpha, _ := system.Alpha(position)
+		if !(alpha > previous && alpha >= 0 && alpha <= 1) {
+			t.Fatalf("Alpha(%d) = %g after %g, want increasing within [0, 1]", position, alpha, previous)
+		}
+
+		previous = alpha
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.25, 1.33)
+	if got := system.ParticipantCount(); got != 500 {
+		t.Errorf("ParticipantCount() = %d, want 500", got)
+	}
+
+	if got := system.ScoreMin(); got != 1000 {
+		t.Errorf("ScoreMin() = %g, want 1000", got)
+	}
+
+	if got := system.ScoreMax(); got != 100000 {
+		t.Errorf("ScoreMax() = %g, want 100000", got)
+	}
+
+	if got := system.Coefficient(); got != 0.25 {
+		t.Errorf("Coefficient() = %g, want 0.25", got)
+	}
+
+	if got := system.Exponent(); got != 1.33 {
+		t.Errorf("Exponent() = %g, want 1.33", got)
+	}
+}
+
+func TestScoreIntRoundsHalfAwayFromZero(t *testing.T) {
+	positive, err := New(2, 1.5, 2.5, 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	negative, err := NewUnbounded(2, -2.5, -1.5, 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		system   *System
+		position uint
+		want     int64
+	}{
+		{positive, 1, 3},
+		{positive, 2, 2},
+		{negative, 1, -2},
+		{negative, 2, -3},
+	}
+
+	for _, test := range tests {
+		if got, ok := test.system.ScoreInt(test.position); !ok || got != test.want {
+			t.Errorf("%v.ScoreInt(%d) = %d, %t, want %d, true", test.system, test.position, got, ok, test.want)
+		}
+	}
+}
+
+func TestScoreIntWithinBounds(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for position := uint(1); position <= 500; position++ {
+		score, ok := system.ScoreInt(position)
+		if !ok || score > 100000 || score < 1000 {
+			t.Fatalf("ScoreInt(%d) = %d, %t, want within [1000, 100000]", position, score, ok)
+		}
+	}
+
+	for _, position := range []uint{0, 501} {
+		if _, ok := system.ScoreInt(position); ok {
+			t.Errorf("ScoreInt(%d) is ok, want false", position)
+		}
+	}
+}
+
+func TestScoreIntOverflow(t *testing.T) {
+	system := newSystem(t, 3, 1, 1e300, 0.5, 1)
+	if score, ok := system.ScoreInt(1); ok {
+		t.Errorf("ScoreInt(1) = %d, true, want false for a score beyond int64", score)
+	}
+
+	if score, ok := system.ScoreInt(3); !ok || score != 1 {
+		t.Errorf("ScoreInt(3) = %d, %t, want 1, true", score, ok)
+	}
+
+	// 2^63 is the first float64 past math.MaxInt64.
+	edge := newSystem(t, 2, 1, math.Exp2(63), 0, 1)
+	if score, ok := edge.ScoreInt(1); ok {
+		t.Errorf("ScoreInt(1) = %d, true, want false for a score of 2^63", score)
+	}
+}
+
+func TestRankInvertsScore(t *testing.T) {
+	for _, exp := range []float64{1, 1.33, 3} {
+		system := newSystem(t, 500, 1000, 100000, 0.5, exp)
+		for position := uint(1); position <= 500; position++ {
+			score, _ := system.Score(position)
+			if got, ok := system.Rank(score); !ok || got != position {
+				t.Fatalf("exp %g: Rank(Score(%d)) = %d, %t, want %d, true", exp, position, got, ok, position)
+			}
+		}
+	}
+}
+
+func TestRankOutOfRange(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for _, score := range []float64{999, 100001, math.NaN()} {
+		if position, ok := system.Rank(score); ok {
+			t.Errorf("Rank(%g) = %d, true, want false", score, position)
+		}
+	}
+}
+
+func TestRankClosest(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	upper, _ := system.Score(10)
+	lower, _ := system.Score(11)
+
+	if got, _ := system.Rank(upper - ((upper - lower) / 4)); got != 10 {
+		t.Errorf("Rank just below Score(10) = %d, want 10", got)
+	}
+
+	if got, _ := system.Rank(lower + ((upper - lower) / 4)); got != 11 {
+		t.Errorf("Rank just above Score(11) = %d, want 11", got)
+	}
+
+	// a linear curve scores 5000, 4000, 3000, 2000 and 1000, so 3500 is exactly between positions 2 and 3.
+	linear := newSystem(t, 5, 1000, 5000, 0, 1)
+	if got, _ := linear.Rank(3500); got != 2 {
+		t.Errorf("Rank(3500) = %d, want the better position 2", got)
+	}
+}
+
+func TestScoreMap(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	scores := system.ScoreMap()
+	if len(scores) != 500 {
+		t.Fatalf("len(ScoreMap()) = %d, want 500", len(scores))
+	}
+
+	for position, score := range scores {
+		want, ok := system.Score(position)
+		if !ok || score != want {
+			t.Errorf("ScoreMap()[%d] = %g, want Score(%d) = %g, %t", position, score, position, want, ok)
+		}
+	}
+}
+
+func TestScoreAllN(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	want := make([]float64, 500)
+	system.ScoreAll(want)
+
+	t.Run("exact", func(t *testing.T) {
+		buf := make([]float64, 500)
+		if n, ok := system.ScoreAllN(buf); !ok || n != 500 {
+			t.Fatalf("ScoreAllN = %d, %t, want 500, true", n, ok)
+		}
+
+		if !slices.Equal(buf, want) {
+			t.Errorf("ScoreAllN did not match ScoreAll")
+		}
+	})
+
+	t.Run("oversized", func(t *testing.T) {
+		buf := make([]float64, 510)
+		for idx := range buf {
+			buf[idx] = -1
+		}
+
+		if n, ok := system.ScoreAllN(buf); !ok || n != 500 {
+			t.Fatalf("ScoreAllN = %d, %t, want 500, true", n, ok)
+		}
+
+		if !slices.Equal(buf[:500], want) {
+			t.Errorf("ScoreAllN did not match ScoreAll")
+		}
+
+		for idx, score := range buf[500:] {
+			if score != -1 {
+				t.Errorf("buf[%d] = %g, want it untouched", 500+idx, score)
+			}
+		}
+	})
+
+	t.Run("undersized", func(t *testing.T) {
+		if n, ok := system.ScoreAllN(make([]float64, 499)); ok {
+			t.Errorf("ScoreAllN = %d, true, want false", n)
+		}
+	})
+}
+
+func TestString(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	want := "bezierscore.System{participants:500, min:1000, max:100000, coeff:0.5, exp:1.33}"
+	if got := system.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			"quantum and reversed",
+			[]Option{WithQuantum(50), WithReversed(true)},
+			"bezierscore.System{participants:500, min:1000, max:100000, coeff:0.5, exp:1.33, " +
+				"reversed:true, quantum:50}",
+		},
+		{
+			"every option",
+			[]Option{
+				WithExponentMode(ExpFrontLoad), WithRoundedControl(true), WithIntegerScores(),
+				WithFloorBelow(400, 2000), WithScoreCap(90000), WithMultiplicativeDecay(0.99),
+			},
+			"bezierscore.System{participants:500, min:1000, max:100000, coeff:0.5, exp:1.33, expmode:1, " +
+				"roundcontrol:true, integerscores:true, floorposition:400, floorscore:2000, scorecap:90000, " +
+				"decay:0.99}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			withOptions, err := system.With(test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := withOptions.String(); got != test.want {
+				t.Errorf("String() = %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	overridden, err := system.WithOverride(10, 90000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overridden, err = overridden.WithOverride(1, 95000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = "bezierscore.System{participants:500, min:1000, max:100000, coeff:0.5, exp:1.33, overrides:1:95000,10:90000}"
+	if got := overridden.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	var nilSystem *System
+
+	tests := []struct {
+		name  string
+		a, b  *System
+		equal bool
+	}{
+		{"same arguments", system, newSystem(t, 500, 1000, 100000, 0.5, 1.33), true},
+		{"within epsilon", system, newSystem(t, 500, 1000, 100000*(1+1e-12), 0.5, 1.33), true},
+		{"different coefficient", system, newSystem(t, 500, 1000, 100000, 0.6, 1.33), false},
+		{"different participant count", system, newSystem(t, 501, 1000, 100000, 0.5, 1.33), false},
+		{"different exponent", system, newSystem(t, 500, 1000, 100000, 0.5, 1.34), false},
+		{"nil argument", system, nil, false},
+		{"nil receiver", nil, system, false},
+		{"both nil", nilSystem, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.a.Equal(test.b); got != test.equal {
+				t.Errorf("%v.Equal(%v) = %t, want %t", test.a, test.b, got, test.equal)
+			}
+		})
+	}
+}
+
+func TestScoreAllParallel(t *testing.T) {
+	system := newSystem(t, 100000, 1000, 100000, 0.5, 1.33)
+	want := make([]float64, 100000)
+	system.ScoreAll(want)
+
+	for _, workers := range []int{-1, 0, 1, 3, 8, 200000} {
+		buf := make([]float64, 100000)
+		if !system.ScoreAllParallel(buf, workers) {
+			t.Fatalf("ScoreAllParallel with %d workers = false, want true", workers)
+		}
+
+		if !slices.Equal(buf, want) {
+			t.Errorf("ScoreAllParallel with %d workers did not match ScoreAll", workers)
+		}
+	}
+
+	if system.ScoreAllParallel(make([]float64, 10), 4) {
+		t.Error("ScoreAllParallel of a short buffer = true, want false")
+	}
+}
+
+func BenchmarkScoreAllParallel(b *testing.B) {
+	for _, participantCount := range []uint{1_000_000, 10_000_000} {
+		system := newSystem(b, participantCount, 1000, 100000, 0.5, 1.33)
+		buf := make([]float64, participantCount)
+		name := strconv.FormatUint(uint64(participantCount), 10)
+
+		b.Run("serial/"+name, func(b *testing.B) {
+			for b.Loop() {
+				system.ScoreAll(buf)
+			}
+		})
+
+		b.Run("parallel/"+name, func(b *testing.B) {
+			for b.Loop() {
+				system.ScoreAllParallel(buf, 0)
+			}
+		})
+	}
+}
+
+func TestCachedControlPoint(t *testing.T) {
+	for _, coeff := range []float64{0, 0.25, 0.5, 1} {
+		system := newSystem(t, 500, 1000, 100000, coeff, 1.33)
+		if cached, computed := system.controlPoint, system.control(); cached != computed {
+			t.Errorf("coeff %g: cached control point %g, want %g", coeff, cached, computed)
+		}
+
+		for position := uint(1); position <= 500; position++ {
+			alpha, _ := system.Alpha(position)
+			want := bezier(system.upperBound, system.lowerBound, system.control(), alpha)
+			if got, _ := system.Score(position); got != want {
+				t.Fatalf("coeff %g: Score(%d) = %g, want %g", coeff, position, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkScoreAll(b *testing.B) {
+	system := newSystem(b, 1_000_000, 1000, 100000, 0.5, 1.33)
+	buf := make([]float64, 1_000_000)
+	b.ReportAllocs()
+	for b.Loop() {
+		system.ScoreAll(buf)
+	}
+}
+
+func TestPercentileEndpoints(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	first, _ := system.Score(1)
+	last, _ := system.Score(500)
+
+	if got, ok := system.Percentile(1); !ok || got != first {
+		t.Errorf("Percentile(1) = %g, %t, want Score(1) = %g", got, ok, first)
+	}
+
+	if got, ok := system.Percentile(0); !ok || got != last {
+		t.Errorf("Percentile(0) = %g, %t, want Score(500) = %g", got, ok, last)
+	}
+
+	if middle, _ := system.Percentile(0.5); !(middle < first && middle > last) {
+		t.Errorf("Percentile(0.5) = %g, want between %g and %g", middle, last, first)
+	}
+
+	for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+		if score, ok := system.Percentile(p); ok {
+			t.Errorf("Percentile(%g) = %g, true, want false", p, score)
+		}
+	}
+}
+
+func TestScoreAllContext(t *testing.T) {
+	system := newSystem(t, 100000, 1000, 100000, 0.5, 1.33)
+
+	want := make([]float64, 100000)
+	system.ScoreAll(want)
+
+	buf := make([]float64, 100000)
+	if err := system.ScoreAllContext(context.Background(), buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(buf, want) {
+		t.Error("ScoreAllContext did not match ScoreAll")
+	}
+
+	if err := system.ScoreAllContext(context.Background(), buf[:10]); !errors.Is(err, BufferLengthErr) {
+		t.Errorf("ScoreAllContext of a short buffer = %v, want BufferLengthErr", err)
+	}
+}
+
+func TestScoreAllContextCancelled(t *testing.T) {
+	system := newSystem(t, 100000, 1000, 100000, 0.5, 1.33)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	buf := make([]float64, 100000)
+	if err := system.ScoreAllContext(ctx, buf); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ScoreAllContext = %v, want context.Canceled", err)
+	}
+
+	// the context is checked before the first score, so nothing is written.
+	for idx, score := range buf {
+		if score != 0 {
+			t.Fatalf("buf[%d] = %g, want it untouched", idx, score)
+		}
+	}
+}
+
+func TestScoreRange(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	tests := []struct {
+		name       string
+		start, end uint
+	}{
+		{"mid-range", 40, 60},
+		{"single position", 250, 250},
+		{"first position", 1, 10},
+		{"last position", 491, 500},
+		{"whole board", 1, 500},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := make([]float64, test.end-test.start+1)
+			if !system.ScoreRange(test.start, test.end, buf) {
+				t.Fatalf("ScoreRange(%d, %d) = false, want true", test.start, test.end)
+			}
+
+			for idx, score := range buf {
+				position := test.start + uint(idx)
+				if want, _ := system.Score(position); score != want {
+					t.Errorf("buf[%d] = %g, want Score(%d) = %g", idx, score, position, want)
+				}
+			}
+		})
+	}
+}
+
+func TestScoreRangeInvalid(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	tests := []struct {
+		name       string
+		start, end uint
+		length     int
+	}{
+		{"zero start", 0, 10, 11},
+		{"start after end", 10, 9, 1},
+		{"end past last", 495, 501, 7},
+		{"short buffer", 1, 10, 9},
+		{"long buffer", 1, 10, 11},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if system.ScoreRange(test.start, test.end, make([]float64, test.length)) {
+				t.Errorf("ScoreRange(%d, %d) of %d = true, want false", test.start, test.end, test.length)
+			}
+		})
+	}
+}
+
+func TestSlopeMatchesFiniteDifference(t *testing.T) {
+	const step = 1e-4
+
+	for _, exp := range []float64{1, 1.33, 3} {
+		system := newSystem(t, 100, 1000, 100000, 0.5, exp)
+		for position := uint(2); position < 100; position++ {
+			slope, ok := system.Slope(position)
+			if !ok {
+				t.Fatalf("Slope(%d) is not ok", position)
+			}
+
+			before, _ := system.ScoreAt(float64(position) - step)
+			after, _ := system.ScoreAt(float64(position) + step)
+			estimate := (after - before) / (2 * step)
+			if math.Abs(slope-estimate) > 1e-4*math.Abs(estimate)+1e-6 {
+				t.Errorf("exp %g: Slope(%d) = %g, want about %g", exp, position, slope, estimate)
+			}
+		}
+	}
+}
+
+func TestSlopeInvalidPosition(t *testing.T) {
+	system := newSystem(t, 100, 1000, 100000, 0.5, 1.33)
+	for _, position := range []uint{0, 101} {
+		if slope, ok := system.Slope(position); ok {
+			t.Errorf("Slope(%d) = %g, true, want false", position, slope)
+		}
+	}
+}
+
+func TestNewSimple(t *testing.T) {
+	system, err := NewSimple(500, 1000, 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := newSystem(t, 500, 1000, 100000, 0.5, 1); !system.Equal(want) {
+		t.Errorf("NewSimple = %v, want %v", system, want)
+	}
+
+	if _, err := NewSimple(1, 1000, 100000); !errors.Is(err, ParticipantCountOutOfRangeErr) {
+		t.Errorf("NewSimple(1, ...) = %v, want ParticipantCountOutOfRangeErr", err)
+	}
+
+	if _, err := NewSimple(500, 0, 100000); !errors.Is(err, ScoreMinOutOfRangeErr) {
+		t.Errorf("NewSimple(500, 0, ...) = %v, want ScoreMinOutOfRangeErr", err)
+	}
+
+	if _, err := NewSimple(500, 1000, 1000); !errors.Is(err, ScoreMaxOutOfRangeErr) {
+		t.Errorf("NewSimple(500, 1000, 1000) = %v, want ScoreMaxOutOfRangeErr", err)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	_, err := New(500, 1000, 100000, 1.5, 1)
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("New = %v, want a *ValidationError", err)
+	}
+
+	if !errors.Is(err, CoefficientOutOfRangeErr) {
+		t.Errorf("New = %v, want it to match CoefficientOutOfRangeErr", err)
+	}
+
+	if validationErr.Field != "coeff" || validationErr.Value != 1.5 {
+		t.Errorf("Field, Value = %q, %g, want \"coeff\", 1.5", validationErr.Field, validationErr.Value)
+	}
+
+	if want := "coeff must be between 0 and 1 inclusive, got coeff=1.5"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestNewRejectsNonFiniteParameters(t *testing.T) {
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		params := []struct {
+			name                           string
+			scoreMin, scoreMax, coeff, exp float64
+		}{
+			{"scoreMin", value, 100000, 0.5, 1.33},
+			{"scoreMax", 1000, value, 0.5, 1.33},
+			{"coeff", 1000, 100000, value, 1.33},
+			{"exp", 1000, 100000, 0.5, value},
+		}
+
+		for _, param := range params {
+			_, err := New(500, param.scoreMin, param.scoreMax, param.coeff, param.exp)
+			if !errors.Is(err, NonFiniteParameterErr) {
+				t.Errorf("New with %s %g = %v, want NonFiniteParameterErr", param.name, value, err)
+			}
+		}
+	}
+}
+
+func TestTotalScore(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	buf := make([]float64, 500)
+	system.ScoreAll(buf)
+
+	want := 0.0
+	for _, score := range buf {
+		want += score
+	}
+
+	if got := system.TotalScore(); got != want {
+		t.Errorf("TotalScore() = %g, want %g", got, want)
+	}
+}
+
+func BenchmarkTotalScore(b *testing.B) {
+	system := newSystem(b, 1_000_000, 1000, 100000, 0.5, 1.33)
+	for b.Loop() {
+		system.TotalScore()
+	}
+}
+
+func TestScoreDelta(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for position := uint(1); position < 500; position++ {
+		delta, ok := system.ScoreDelta(position)
+		current, _ := system.Score(position)
+		next, _ := system.Score(position + 1)
+		if !ok || delta != current-next || !(delta > 0) {
+			t.Fatalf("ScoreDelta(%d) = %g, %t, want positive %g", position, delta, ok, current-next)
+		}
+	}
+
+	for _, position := range []uint{0, 500, 501} {
+		if delta, ok := system.ScoreDelta(position); ok {
+			t.Errorf("ScoreDelta(%d) = %g, true, want false", position, delta)
+		}
+	}
+}
+
+func TestNewCubicShape(t *testing.T) {
+	cubic, err := NewCubic(101, 1000, 100000, 0, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quadratic := newSystem(t, 101, 1000, 100000, 0, 1)
+	for _, position := range []uint{1, 101} {
+		want, _ := quadratic.Score(position)
+		if got, _ := cubic.Score(position); got != want {
+			t.Errorf("cubic Score(%d) = %g, want the same endpoint as quadratic %g", position, got, want)
+		}
+	}
+
+	// with both control points in the middle, the cubic is an S-curve: steeper than the straight quadratic at the
+	// ends, and flatter in the middle.
+	for _, position := range []uint{1, 101} {
+		cubicSlope, _ := cubic.Slope(position)
+		quadraticSlope, _ := quadratic.Slope(position)
+		if !(math.Abs(cubicSlope) > math.Abs(quadraticSlope)) {
+			t.Errorf("cubic Slope(%d) = %g, want steeper than quadratic %g", position, cubicSlope, quadraticSlope)
+		}
+	}
+
+	cubicSlope, _ := cubic.Slope(51)
+	quadraticSlope, _ := quadratic.Slope(51)
+	if !(math.Abs(cubicSlope) < math.Abs(quadraticSlope)) {
+		t.Errorf("cubic Slope(51) = %g, want flatter than quadratic %g", cubicSlope, quadraticSlope)
+	}
+
+	if middle, _ := cubic.Score(51); middle != 50500 {
+		t.Errorf("cubic Score(51) = %g, want the middle of the score range 50500", middle)
+	}
+}
+
+func TestNewCubicValidatesCoefficients(t *testing.T) {
+	for _, coeffs := range [][2]float64{{-0.1, 0.5}, {1.1, 0.5}, {0.5, -0.1}, {0.5, 1.1}} {
+		_, err := NewCubic(500, 1000, 100000, coeffs[0], coeffs[1], 1)
+		if !errors.Is(err, CoefficientOutOfRangeErr) {
+			t.Errorf("NewCubic with coeffs %g = %v, want CoefficientOutOfRangeErr", coeffs, err)
+		}
+	}
+
+	for _, coeffs := range [][2]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+		if _, err := NewCubic(500, 1000, 100000, coeffs[0], coeffs[1], 1); err != nil {
+			t.Errorf("NewCubic with coeffs %g = %v, want no error", coeffs, err)
+		}
+	}
+}
+
+// TestScoreConcurrent hammers Score from many goroutines, so that go test -race reports any data race in the scoring
+// path.
+func TestScoreConcurrent(t *testing.T) {
+	system := newSystem(t, 1000, 1000, 100000, 0.5, 1.33)
+	want := make([]float64, 1000)
+	system.ScoreAll(want)
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Go(func() {
+			for range 20 {
+				for position := uint(1); position <= 1000; position++ {
+					if got, _ := system.Score(position); got != want[position-1] {
+						t.Errorf("Score(%d) = %g, want %g", position, got, want[position-1])
+						return
+					}
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+func TestScoreAllFloat32(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	want := make([]float64, 500)
+	system.ScoreAll(want)
+
+	buf := make([]float32, 500)
+	if !system.ScoreAllFloat32(buf) {
+		t.Fatal("ScoreAllFloat32 = false, want true")
+	}
+
+	for idx, score := range buf {
+		if score != float32(want[idx]) {
+			t.Fatalf("buf[%d] = %g, want %g rounded to float32", idx, score, want[idx])
+		}
+
+		if math.Abs(float64(score)-want[idx]) > 1e-6*want[idx] {
+			t.Fatalf("buf[%d] = %g, want within float32 tolerance of %g", idx, score, want[idx])
+		}
+	}
+
+	if system.ScoreAllFloat32(make([]float32, 499)) {
+		t.Error("ScoreAllFloat32 of a short buffer = true, want false")
+	}
+}
+
+func TestScoreNormalized(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	reversed, err := system.With(WithReversed(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		system      *System
+		first, last float64
+	}{
+		{"forward", system, 1, 0},
+		{"reversed", reversed, 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, ok := test.system.ScoreNormalized(1); !ok || got != test.first {
+				t.Errorf("ScoreNormalized(1) = %g, %t, want %g", got, ok, test.first)
+			}
+
+			if got, ok := test.system.ScoreNormalized(500); !ok || got != test.last {
+				t.Errorf("ScoreNormalized(500) = %g, %t, want %g", got, ok, test.last)
+			}
+
+			for position := uint(1); position <= 500; position++ {
+				if n, _ := test.system.ScoreNormalized(position); !(n >= 0 && n <= 1) {
+					t.Fatalf("ScoreNormalized(%d) = %g, want within [0, 1]", position, n)
+				}
+			}
+
+			if _, ok := test.system.ScoreNormalized(0); ok {
+				t.Error("ScoreNormalized(0) is ok, want false")
+			}
+		})
+	}
+}
+
+func TestNewWithControl(t *testing.T) {
+	system, err := NewWithControl(500, 1000, 100000, 50500, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := system.ControlPoint(); got != 50500 {
+		t.Errorf("ControlPoint() = %g, want 50500", got)
+	}
+
+	// a control point at the midpoint reproduces a coeff of 0.
+	want := newSystem(t, 500, 1000, 100000, 0, 1.33)
+	for position := uint(1); position <= 500; position++ {
+		wantScore, _ := want.Score(position)
+		if got, _ := system.Score(position); got != wantScore {
+			t.Fatalf("Score(%d) = %g, want %g", position, got, wantScore)
+		}
+	}
+
+	for _, controlPoint := range []float64{999, 100001} {
+		_, err := NewWithControl(500, 1000, 100000, controlPoint, 1.33)
+		if !errors.Is(err, ControlPointOutOfRangeErr) {
+			t.Errorf("NewWithControl with control point %g = %v, want ControlPointOutOfRangeErr", controlPoint, err)
+		}
+	}
+}
+
+func TestEntries(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	entries := system.Entries()
+	if len(entries) != 500 {
+		t.Fatalf("len(Entries()) = %d, want 500", len(entries))
+	}
+
+	for idx, entry := range entries {
+		want, _ := system.Score(uint(idx) + 1)
+		if entry.Position != uint(idx)+1 || entry.Score != want {
+			t.Fatalf("Entries()[%d] = %+v, want {Position:%d Score:%g}", idx, entry, idx+1, want)
+		}
+	}
+}
+
+func TestScoreTie(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	first, _ := system.Score(1)
+	second, _ := system.Score(2)
+	if got, ok := system.ScoreTie(1, 2); !ok || got != (first+second)/2 {
+		t.Errorf("ScoreTie(1, 2) = %g, %t, want %g", got, ok, (first+second)/2)
+	}
+
+	want := 0.0
+	for position := uint(497); position <= 500; position++ {
+		score, _ := system.Score(position)
+		want += score
+	}
+
+	if got, ok := system.ScoreTie(497, 4); !ok || got != want/4 {
+		t.Errorf("ScoreTie(497, 4) = %g, %t, want %g", got, ok, want/4)
+	}
+
+	for _, tie := range [][2]uint{{0, 2}, {1, 0}, {498, 4}, {501, 1}} {
+		if score, ok := system.ScoreTie(tie[0], tie[1]); ok {
+			t.Errorf("ScoreTie(%d, %d) = %g, true, want false", tie[0], tie[1], score)
+		}
+	}
+}
+
+func TestMaxScoreMinScore(t *testing.T) {
+	system, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).With(WithScoreCap(90000), WithFloorBelow(400, 2000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	highest, lowest := math.Inf(-1), math.Inf(1)
+	for position := uint(1); position <= 500; position++ {
+		score, _ := system.Score(position)
+		highest, lowest = max(highest, score), min(lowest, score)
+	}
+
+	if got := system.MaxScore(); got != highest || got != 90000 {
+		t.Errorf("MaxScore() = %g, want %g", got, highest)
+	}
+
+	if got := system.MinScore(); got != lowest || got != 2000 {
+		t.Errorf("MinScore() = %g, want %g", got, lowest)
+	}
+}
+
+func TestAll(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	want := make([]float64, 500)
+	system.ScoreAll(want)
+
+	var got []float64
+	for position, score := range system.All() {
+		if position != uint(len(got))+1 {
+			t.Fatalf("All yielded position %d after %d scores", position, len(got))
+		}
+
+		got = append(got, score)
+	}
+
+	if !slices.Equal(got, want) {
+		t.Error("All did not match ScoreAll")
+	}
+}
+
+func TestAllBreak(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	count := 0
+	for position := range system.All() {
+		count++
+		if position == 10 {
+			break
+		}
+	}
+
+	if count != 10 {
+		t.Errorf("All yielded %d positions before the break, want 10", count)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for _, bins := range []int{1, 7, 10, 500} {
+		counts, err := system.Histogram(bins)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		total := uint(0)
+		for _, count := range counts {
+			total += count
+		}
+
+		if len(counts) != bins || total != 500 {
+			t.Errorf("Histogram(%d) = %d, want %d bins summing to 500", bins, counts, bins)
+		}
+	}
+
+	var validationErr *ValidationError
+	if _, err := system.Histogram(0); !errors.As(err, &validationErr) || validationErr.Field != "bins" ||
+		!errors.Is(err, BinCountOutOfRangeErr) {
+		t.Errorf("Histogram(0) = %v, want a *ValidationError for bins", err)
+	}
+}
+
+func TestHistogramUniform(t *testing.T) {
+	// a linear curve scores 5000, 4000, 3000, 2000 and 1000, so 3000 lies on the boundary between the two bins and is
+	// counted in the lower one.
+	counts, err := newSystem(t, 5, 1000, 5000, 0, 1).Histogram(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []uint{3, 2}; !slices.Equal(counts, want) {
+		t.Errorf("Histogram(2) = %d, want %d", counts, want)
+	}
+
+	counts, err = newSystem(t, 1000, 1000, 100000, 0, 1).Histogram(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for idx, count := range counts {
+		if count < 99 || count > 101 {
+			t.Errorf("Histogram(10)[%d] = %d, want about 100", idx, count)
+		}
+	}
+}
+
+func TestLastPositionAbove(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	tenth, _ := system.Score(10)
+	eleventh, _ := system.Score(11)
+
+	tests := []struct {
+		name      string
+		threshold float64
+		want      uint
+		ok        bool
+	}{
+		{"between two scores", (tenth / 2) + (eleventh / 2), 10, true},
+		{"exactly a score", tenth, 10, true},
+		{"above the maximum", 100001, 0, false},
+		{"exactly the maximum", 100000, 1, true},
+		{"below the minimum", 0, 500, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, ok := system.LastPositionAbove(test.threshold); got != test.want || ok != test.ok {
+				t.Errorf("LastPositionAbove(%g) = %d, %t, want %d, %t", test.threshold, got, ok, test.want, test.ok)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	b := newSystem(t, 500, 1000, 100000, 0.5, 2)
+	short := newSystem(t, 100, 1000, 100000, 0.5, 1.33)
+
+	aScore, bScore, diff, ok := Compare(a, a, 10)
+	if want, _ := a.Score(10); !ok || aScore != want || bScore != want || diff != 0 {
+		t.Errorf("Compare(a, a, 10) = %g, %g, %g, %t, want %g, %g, 0, true", aScore, bScore, diff, ok, want, want)
+	}
+
+	aScore, bScore, diff, ok = Compare(a, b, 10)
+	wantA, _ := a.Score(10)
+	wantB, _ := b.Score(10)
+	if !ok || aScore != wantA || bScore != wantB || diff != wantA-wantB {
+		t.Errorf("Compare(a, b, 10) = %g, %g, %g, %t, want %g, %g, %g, true",
+			aScore, bScore, diff, ok, wantA, wantB, wantA-wantB)
+	}
+
+	if _, _, _, ok := Compare(a, short, 100); !ok {
+		t.Error("Compare(a, short, 100) is not ok, want ok for a position valid for both")
+	}
+
+	for _, position := range []uint{0, 101} {
+		if _, _, _, ok := Compare(a, short, position); ok {
+			t.Errorf("Compare(a, short, %d) is ok, want false", position)
+		}
+	}
+}
+
+func TestAppendScores(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	want := make([]float64, 500)
+	system.ScoreAll(want)
+
+	dst := []float64{-1, -2, -3}
+	got := system.AppendScores(dst)
+	if len(got) != 503 {
+		t.Fatalf("len(AppendScores) = %d, want 503", len(got))
+	}
+
+	if !slices.Equal(got[:3], []float64{-1, -2, -3}) {
+		t.Errorf("AppendScores overwrote the existing contents: %g", got[:3])
+	}
+
+	if !slices.Equal(got[3:], want) {
+		t.Error("AppendScores did not match ScoreAll")
+	}
+
+	// with enough capacity, dst's backing array is reused.
+	dst = make([]float64, 1, 600)
+	if got := system.AppendScores(dst); &got[0] != &dst[0] {
+		t.Error("AppendScores reallocated a slice with enough capacity")
+	}
+}
+
+func TestNewWithEasing(t *testing.T) {
+	identity, err := NewWithEasing(500, 1000, 100000, 0.5, func(alpha float64) float64 { return alpha })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linear := newSystem(t, 500, 1000, 100000, 0.5, 1)
+	for position := uint(1); position <= 500; position++ {
+		want, _ := linear.Score(position)
+		if got, _ := identity.Score(position); got != want {
+			t.Fatalf("identity easing Score(%d) = %g, want %g as with exp 1", position, got, want)
+		}
+	}
+
+	// a logistic curve normalized to pass through 0 and 1.
+	sigmoid := func(alpha float64) float64 {
+		logistic := func(x float64) float64 { return 1 / (1 + math.Exp(-12*(x-0.5))) }
+		return (logistic(alpha) - logistic(0)) / (logistic(1) - logistic(0))
+	}
+
+	eased, err := NewWithEasing(500, 1000, 100000, 0.5, sigmoid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for position := uint(1); position <= 500; position++ {
+		alpha, _ := eased.Alpha(position)
+		if want := sigmoid(float64(position-1) / 499); alpha != want {
+			t.Fatalf("Alpha(%d) = %g, want %g", position, alpha, want)
+		}
+	}
+
+	if !eased.IsMonotonic() {
+		t.Error("sigmoid easing is not monotonic")
+	}
+}
+
+func TestNewWithEasingRejectsOutOfRange(t *testing.T) {
+	ease := func(alpha float64) float64 { return alpha * 2 }
+	if _, err := NewWithEasing(500, 1000, 100000, 0.5, ease); !errors.Is(err, EasingOutOfRangeErr) {
+		t.Errorf("NewWithEasing = %v, want EasingOutOfRangeErr", err)
+	}
+}
+
+func TestScoreUnchecked(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for position := uint(1); position <= 500; position++ {
+		if want, _ := system.Score(position); system.ScoreUnchecked(position) != want {
+			t.Fatalf("ScoreUnchecked(%d) = %g, want %g", position, system.ScoreUnchecked(position), want)
+		}
+	}
+}
+
+func BenchmarkScore(b *testing.B) {
+	system := newSystem(b, 500, 1000, 100000, 0.5, 1.33)
+
+	b.Run("checked", func(b *testing.B) {
+		for b.Loop() {
+			for position := uint(1); position <= 500; position++ {
+				system.Score(position)
+			}
+		}
+	})
+
+	b.Run("unchecked", func(b *testing.B) {
+		for b.Loop() {
+			for position := uint(1); position <= 500; position++ {
+				system.ScoreUnchecked(position)
+			}
+		}
+	})
+}
+
+func TestTopN(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	all := make([]float64, 500)
+	system.ScoreAll(all)
+
+	for _, n := range []uint{1, 10, 500} {
+		scores, err := system.TopN(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(scores, all[:n]) {
+			t.Errorf("TopN(%d) = %g, want %g", n, scores, all[:n])
+		}
+	}
+
+	for _, n := range []uint{0, 501} {
+		var validationErr *ValidationError
+		if _, err := system.TopN(n); !errors.As(err, &validationErr) || validationErr.Field != "n" ||
+			!errors.Is(err, TopNOutOfRangeErr) {
+			t.Errorf("TopN(%d) = %v, want a *ValidationError for n", n, err)
+		}
+	}
+}
+
+func TestAlphaEndpoints(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	reversed, err := system.With(WithReversed(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eased, err := NewWithEasing(500, 1000, 100000, 0.5, func(alpha float64) float64 { return alpha * alpha })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		system      *System
+		first, last float64
+	}{
+		{"forward", system, 0, 1},
+		{"reversed", reversed, 1, 0},
+		{"eased", eased, 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, ok := test.system.Alpha(1); !ok || got != test.first {
+				t.Errorf("Alpha(1) = %g, %t, want %g", got, ok, test.first)
+			}
+
+			if got, ok := test.system.Alpha(500); !ok || got != test.last {
+				t.Errorf("Alpha(500) = %g, %t, want %g", got, ok, test.last)
+			}
+
+			for _, position := range []uint{0, 501} {
+				if alpha, ok := test.system.Alpha(position); ok {
+					t.Errorf("Alpha(%d) = %g, true, want false", position, alpha)
+				}
+			}
+		})
+	}
+
+	// alpha is reported after easing, so the middle of five positions is at 0.5^2.
+	eased, err = NewWithEasing(5, 1000, 100000, 0.5, func(alpha float64) float64 { return alpha * alpha })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if alpha, _ := eased.Alpha(3); alpha != 0.25 {
+		t.Errorf("eased Alpha(3) = %g, want 0.25", alpha)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := System{
+		participantCount:   500,
+		upperBound:         100000,
+		lowerBound:         1000,
+		controlCoefficient: 0.5,
+		exponent:           1.33,
+	}
+
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *System)
+		want   error
+	}{
+		{"non-finite", func(s *System) { s.exponent = math.NaN() }, NonFiniteParameterErr},
+		{"too few participants", func(s *System) { s.participantCount = 1 }, ParticipantCountOutOfRangeErr},
+		{"too many participants", func(s *System) { s.participantCount = 1 << 31 }, ParticipantCountTooLargeErr},
+		{"scoreMin below 1", func(s *System) { s.lowerBound = 0.5 }, ScoreMinOutOfRangeErr},
+		{"scoreMax not above scoreMin", func(s *System) { s.upperBound = 1000 }, ScoreMaxOutOfRangeErr},
+		{"coeff below 0", func(s *System) { s.controlCoefficient = -0.1 }, CoefficientOutOfRangeErr},
+		{"coeff above 1", func(s *System) { s.controlCoefficient = 1.1 }, CoefficientOutOfRangeErr},
+		{"exp below 1", func(s *System) { s.exponent = 0.9 }, ExponentOutOfRangeErr},
+		{"quantum below 0", func(s *System) { s.quantum = -1 }, QuantumOutOfRangeErr},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			system := valid
+			test.modify(&system)
+			if err := system.Validate(); !errors.Is(err, test.want) {
+				t.Errorf("Validate() = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestScoreSliceIndependent(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	a, b := system.ScoreSlice(), system.ScoreSlice()
+	if len(a) != 500 || !slices.Equal(a, b) {
+		t.Fatalf("ScoreSlice returned %d and %d differing scores, want 500 identical scores", len(a), len(b))
+	}
+
+	a[0] = -1
+	if b[0] == -1 {
+		t.Error("ScoreSlice returned slices sharing a backing array")
+	}
+
+	if first, _ := system.Score(1); system.ScoreSlice()[0] != first {
+		t.Error("modifying a ScoreSlice changed later results")
+	}
+}
+
+func TestNewUnbounded(t *testing.T) {
+	system, err := NewUnbounded(500, -100, 0, 0.5, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first, _ := system.Score(1); first != 0 {
+		t.Errorf("Score(1) = %g, want 0", first)
+	}
+
+	if last, _ := system.Score(500); last != -100 {
+		t.Errorf("Score(500) = %g, want -100", last)
+	}
+
+	if !system.IsMonotonic() {
+		t.Error("IsMonotonic() = false, want true")
+	}
+
+	if _, err := New(500, -100, 0, 0.5, 1.33); !errors.Is(err, ScoreMinOutOfRangeErr) {
+		t.Errorf("New with a negative scoreMin = %v, want ScoreMinOutOfRangeErr", err)
+	}
+
+	if _, err := NewUnbounded(500, 0, -100, 0.5, 1.33); !errors.Is(err, ScoreMaxOutOfRangeErr) {
+		t.Errorf("NewUnbounded with scoreMax below scoreMin = %v, want ScoreMaxOutOfRangeErr", err)
+	}
+
+	if _, err := NewUnbounded(500, math.Inf(-1), 0, 0.5, 1.33); !errors.Is(err, NonFiniteParameterErr) {
+		t.Errorf("NewUnbounded with an infinite scoreMin = %v, want NonFiniteParameterErr", err)
+	}
+}
+
+func TestMustNew(t *testing.T) {
+	system := MustNew(500, 1000, 100000, 0.5, 1.33)
+	if first, ok := system.Score(1); !ok || first != 100000 {
+		t.Errorf("Score(1) = %g, %t, want 100000, true", first, ok)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, CoefficientOutOfRangeErr) {
+			t.Errorf("MustNew panicked with %v, want CoefficientOutOfRangeErr", err)
+		}
+	}()
+
+	MustNew(500, 1000, 100000, 1.5, 1.33)
+	t.Error("MustNew with invalid parameters did not panic")
+}
+
+func TestScoreAt(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for position := uint(1); position <= 500; position++ {
+		want, _ := system.Score(position)
+		if got, ok := system.ScoreAt(float64(position)); !ok || got != want {
+			t.Fatalf("ScoreAt(%d) = %g, %t, want %g", position, got, ok, want)
+		}
+	}
+
+	for _, position := range []float64{1.5, 3.5, 250.25, 499.9} {
+		got, ok := system.ScoreAt(position)
+		better, _ := system.Score(uint(position))
+		worse, _ := system.Score(uint(position) + 1)
+		if !ok || !(got < better && got > worse) {
+			t.Errorf("ScoreAt(%g) = %g, %t, want between %g and %g", position, got, ok, worse, better)
+		}
+	}
+
+	for _, position := range []float64{0.5, 500.5, math.NaN()} {
+		if score, ok := system.ScoreAt(position); ok {
+			t.Errorf("ScoreAt(%g) = %g, true, want false", position, score)
+		}
+	}
+}
+
+func TestNewBatch(t *testing.T) {
+	configs := []Config{
+		{ParticipantCount: 500, ScoreMin: 1000, ScoreMax: 100000, Coefficient: 0.5, Exponent: 1.33},
+		{ParticipantCount: 10, ScoreMin: 1, ScoreMax: 10, Coefficient: 0, Exponent: 1},
+	}
+
+	systems, err := NewBatch(configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(systems) != len(configs) {
+		t.Fatalf("NewBatch returned %d Systems, want %d", len(systems), len(configs))
+	}
+
+	for idx, c := range configs {
+		want := newSystem(t, c.ParticipantCount, c.ScoreMin, c.ScoreMax, c.Coefficient, c.Exponent)
+		if !systems[idx].Equal(want) {
+			t.Errorf("NewBatch()[%d] = %v, want %v", idx, systems[idx], want)
+		}
+	}
+}
+
+func TestNewBatchReportsIndex(t *testing.T) {
+	configs := []Config{
+		{ParticipantCount: 500, ScoreMin: 1000, ScoreMax: 100000, Coefficient: 0.5, Exponent: 1.33},
+		{ParticipantCount: 500, ScoreMin: 1000, ScoreMax: 100000, Coefficient: 0.5, Exponent: 1.33},
+		{ParticipantCount: 500, ScoreMin: 1000, ScoreMax: 100000, Coefficient: 0.5, Exponent: 0.5},
+	}
+
+	systems, err := NewBatch(configs)
+	if systems != nil {
+		t.Errorf("NewBatch returned %d Systems, want none", len(systems))
+	}
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || batchErr.Index != 2 {
+		t.Fatalf("NewBatch = %v, want a *BatchError for index 2", err)
+	}
+
+	if !errors.Is(err, ExponentOutOfRangeErr) {
+		t.Errorf("NewBatch = %v, want it to match ExponentOutOfRangeErr", err)
+	}
+}
+
+func TestCurvature(t *testing.T) {
+	frontLoaded, err := newSystem(t, 500, 1000, 100000, 0, 2).With(WithExponentMode(ExpFrontLoad))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lowControl, err := NewWithControl(500, 1000, 100000, 20000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sCurve, err := NewCubic(500, 1000, 100000, 0, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		system *System
+		want   int
+	}{
+		{"linear", newSystem(t, 500, 1000, 100000, 0, 1), 0},
+		{"high control point", newSystem(t, 500, 1000, 100000, 0.5, 1), -1},
+		{"back-loading exponent", newSystem(t, 500, 1000, 100000, 0, 2), -1},
+		{"front-loading exponent", frontLoaded, 1},
+		{"low control point", lowControl, 1},
+		{"s-curve", sCurve, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.system.Curvature(); got != test.want {
+				t.Errorf("Curvature() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestScoreIntMode(t *testing.T) {
+	// first place scores 2.25 and -1.75 respectively.
+	positive, err := New(2, 1.25, 2.25, 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	negative, err := NewUnbounded(2, -2.75, -1.75, 0.5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		mode               RoundMode
+		positive, negative int64
+	}{
+		{RoundNearest, 2, -2},
+		{RoundFloor, 2, -2},
+		{RoundCeil, 3, -1},
+		{RoundTowardZero, 2, -1},
+	}
+
+	for _, test := range tests {
+		if got, ok := positive.ScoreIntMode(1, test.mode); !ok || got != test.positive {
+			t.Errorf("ScoreIntMode(1, %d) of 2.25 = %d, %t, want %d", test.mode, got, ok, test.positive)
+		}
+
+		if got, ok := negative.ScoreIntMode(1, test.mode); !ok || got != test.negative {
+			t.Errorf("ScoreIntMode(1, %d) of -1.75 = %d, %t, want %d", test.mode, got, ok, test.negative)
+		}
+	}
+
+	if score, ok := positive.ScoreIntMode(1, RoundMode(99)); ok {
+		t.Errorf("ScoreIntMode with an unknown mode = %d, true, want false", score)
+	}
+
+	if score, ok := positive.ScoreIntMode(3, RoundFloor); ok {
+		t.Errorf("ScoreIntMode(3) = %d, true, want false", score)
+	}
+}
+
+func TestSamplePosition(t *testing.T) {
+	system := newSystem(t, 10, 1000, 100000, 0.5, 1.33)
+	if position, ok := system.SamplePosition(0); !ok || position != 1 {
+		t.Errorf("SamplePosition(0) = %d, %t, want 1, true", position, ok)
+	}
+
+	if position, ok := system.SamplePosition(math.Nextafter(1, 0)); !ok || position != 10 {
+		t.Errorf("SamplePosition(just below 1) = %d, %t, want 10, true", position, ok)
+	}
+
+	// sweeping r evenly over [0, 1) samples each position in proportion to its share of the total.
+	const samples = 100000
+	counts := make([]int, 10)
+	for idx := range samples {
+		position, ok := system.SamplePosition(float64(idx) / samples)
+		if !ok {
+			t.Fatalf("SamplePosition(%g) is not ok", float64(idx)/samples)
+		}
+
+		counts[position-1]++
+	}
+
+	total := system.TotalScore()
+	sum := 0
+	for idx, count := range counts {
+		sum += count
+		score, _ := system.Score(uint(idx) + 1)
+		if want := samples * score / total; math.Abs(float64(count)-want) > 1 {
+			t.Errorf("position %d sampled %d times, want about %g", idx+1, count, want)
+		}
+	}
+
+	if sum != samples {
+		t.Errorf("positions were sampled %d times in total, want %d", sum, samples)
+	}
+
+	for _, r := range []float64{-0.1, 1, 1.5, math.NaN()} {
+		if position, ok := system.SamplePosition(r); ok {
+			t.Errorf("SamplePosition(%g) = %d, true, want false", r, position)
+		}
+	}
+}
+
+func FuzzScore(f *testing.F) {
+	f.Add(uint(500), 1000.0, 100000.0, 0.5, 1.33)
+	f.Add(uint(2), 1.0, 2.0, 0.0, 1.0)
+	f.Add(uint(3), 1.0, math.MaxFloat64, 1.0, 1.0)
+	f.Add(uint(10), math.SmallestNonzeroFloat64, 1.0, 0.5, 1e300)
+	f.Add(uint(1000), 1.0, 1e-300, 0.5, 2.0)
+	f.Add(uint(58), 1.0, math.MaxFloat64, 1.0, 7.199999999999999)
+
+	f.Fuzz(func(t *testing.T, participantCount uint, scoreMin, scoreMax, coeff, exp float64) {
+		// keep each input quick to scan.
+		participantCount %= 10000
+
+		system, err := New(participantCount, scoreMin, scoreMax, coeff, exp)
+		if err != nil {
+			return
+		}
+
+		// score the endpoints and a spread of positions in between.
+		step := max(participantCount/100, 1)
+		for position := uint(1); position <= participantCount; position += step {
+			for _, candidate := range []uint{position, participantCount} {
+				score, ok := system.Score(candidate)
+				if !ok {
+					t.Fatalf("%v.Score(%d) is not ok", system, candidate)
+				}
+
+				if math.IsNaN(score) || math.IsInf(score, 0) {
+					t.Fatalf("%v.Score(%d) = %g, want a finite score", system, candidate, score)
+				}
+			}
+		}
+	})
+}
+
+func TestScoreNearFloatLimits(t *testing.T) {
+	scoreMin, scoreMax := math.MaxFloat64/2, math.MaxFloat64
+
+	// the bounds are too large to sum before halving them for the midpoint, or to sum the terms of the curve before
+	// weighting them.
+	if naive := (scoreMin + scoreMax) / 2; !math.IsInf(naive, 1) {
+		t.Fatalf("naive midpoint = %g, want it to overflow", naive)
+	}
+
+	for _, coeff := range []float64{0, 0.5, 1} {
+		for _, exp := range []float64{1, 1.33, 7.2} {
+			system := newSystem(t, 58, scoreMin, scoreMax, coeff, exp)
+			if math.IsInf(system.ControlPoint(), 0) {
+				t.Errorf("coeff %g, exp %g: ControlPoint() = %g, want it finite", coeff, exp, system.ControlPoint())
+			}
+
+			for position := uint(1); position <= 58; position++ {
+				if score, _ := system.Score(position); !(score >= scoreMin && score <= scoreMax) {
+					t.Fatalf("coeff %g, exp %g: Score(%d) = %g, want it within range", coeff, exp, position, score)
+				}
+			}
+		}
+	}
+
+	// found by FuzzScore: the first two terms of the curve are each finite, but their sum rounds past MaxFloat64.
+	system := newSystem(t, 58, 1, math.MaxFloat64, 1, 7.199999999999999)
+	if score, _ := system.Score(3); math.IsInf(score, 0) {
+		t.Errorf("Score(3) = %g, want it finite", score)
+	}
+}
+
+func TestSystem32NearFloatLimits(t *testing.T) {
+	for _, coeff := range []float32{0, 0.5, 1} {
+		system, err := New32(58, math.MaxFloat32/2, math.MaxFloat32, coeff, 7.2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for position := uint(1); position <= 58; position++ {
+			if score, _ := system.Score(position); math.IsInf(float64(score), 0) || math.IsNaN(float64(score)) {
+				t.Fatalf("coeff %g: Score(%d) = %g, want it finite", coeff, position, score)
+			}
+		}
+	}
+}
+
+func TestScoreRatio(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	if ratio, ok := system.ScoreRatio(1); !ok || ratio != 1 {
+		t.Errorf("ScoreRatio(1) = %g, %t, want 1, true", ratio, ok)
+	}
+
+	previous := 1.0
+	for position := uint(2); position <= 500; position++ {
+		ratio, ok := system.ScoreRatio(position)
+		if !ok || !(ratio < previous) {
+			t.Fatalf("ScoreRatio(%d) = %g, %t, want less than %g", position, ratio, ok, previous)
+		}
+
+		previous = ratio
+	}
+
+	if ratio, _ := system.ScoreRatio(500); ratio != 0.01 {
+		t.Errorf("ScoreRatio(500) = %g, want 0.01", ratio)
+	}
+
+	golf, err := NewUnbounded(500, -100, 0, 0.5, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ratio, ok := golf.ScoreRatio(10); ok {
+		t.Errorf("ScoreRatio(10) with first place scoring 0 = %g, true, want false", ratio)
+	}
+}
+
+func TestScoreAllFunc(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	want := make([]float64, 500)
+	system.ScoreAll(want)
+
+	type player struct {
+		name  string
+		score float64
+	}
+
+	players := make([]player, 500)
+	ok := system.ScoreAllFunc(uint(len(players)), func(position uint, score float64) {
+		players[position-1].score = score
+	})
+
+	if !ok {
+		t.Fatal("ScoreAllFunc = false, want true")
+	}
+
+	for idx, player := range players {
+		if player.score != want[idx] {
+			t.Fatalf("players[%d].score = %g, want %g", idx, player.score, want[idx])
+		}
+	}
+
+	called := false
+	if system.ScoreAllFunc(499, func(uint, float64) { called = true }) || called {
+		t.Error("ScoreAllFunc(499) = true or called set, want false without calling set")
+	}
+}
+
+func TestMaxParticipantCount(t *testing.T) {
+	system, err := New(MaxParticipantCount, 1000, 100000, 0.5, 1.33)
+	if err != nil {
+		t.Fatalf("New(MaxParticipantCount, ...) = %v, want no error", err)
+	}
+
+	if last, _ := system.Score(MaxParticipantCount); last != 1000 {
+		t.Errorf("Score(MaxParticipantCount) = %g, want 1000", last)
+	}
+
+	if alpha, _ := system.Alpha(MaxParticipantCount - 1); !(alpha > 0 && alpha < 1) {
+		t.Errorf("Alpha(MaxParticipantCount-1) = %g, want strictly between 0 and 1", alpha)
+	}
+
+	if _, err := New(MaxParticipantCount+1, 1000, 100000, 0.5, 1.33); !errors.Is(err, ParticipantCountTooLargeErr) {
+		t.Errorf("New(MaxParticipantCount+1, ...) = %v, want ParticipantCountTooLargeErr", err)
+	}
+}
+
+func TestNewPiecewise(t *testing.T) {
+	top := newSystem(t, 50, 10000, 100000, 0.5, 1.33)
+	bottom := newSystem(t, 451, 1000, 10000, 0, 1)
+	system, err := NewPiecewise(500, 50, top, bottom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each System's participant count matches the length of its segment, so positions line up exactly.
+	for position := uint(1); position <= 50; position++ {
+		want, _ := top.Score(position)
+		if got, _ := system.Score(position); got != want {
+			t.Fatalf("Score(%d) = %g, want top.Score(%d) = %g", position, got, position, want)
+		}
+	}
+
+	for position := uint(50); position <= 500; position++ {
+		want, _ := bottom.Score(position - 49)
+		if got, _ := system.Score(position); got != want {
+			t.Fatalf("Score(%d) = %g, want bottom.Score(%d) = %g", position, got, position-49, want)
+		}
+	}
+
+	// the segments meet at 10000, so the curve is continuous across the split.
+	if split, _ := system.Score(50); split != 10000 {
+		t.Errorf("Score(50) = %g, want 10000", split)
+	}
+
+	if !system.IsMonotonic() {
+		t.Error("IsMonotonic() = false, want true")
+	}
+}
+
+func TestNewPiecewiseValidates(t *testing.T) {
+	top := newSystem(t, 50, 10000, 100000, 0.5, 1.33)
+	bottom := newSystem(t, 451, 1000, 10000, 0, 1)
+
+	for _, split := range []uint{0, 1, 500, 501} {
+		if _, err := NewPiecewise(500, split, top, bottom); !errors.Is(err, SplitPositionOutOfRangeErr) {
+			t.Errorf("NewPiecewise with split %d = %v, want SplitPositionOutOfRangeErr", split, err)
+		}
+	}
+
+	if _, err := NewPiecewise(500, 50, nil, bottom); !errors.Is(err, NilSystemErr) {
+		t.Errorf("NewPiecewise with a nil top = %v, want NilSystemErr", err)
+	}
+
+	if _, err := NewPiecewise(500, 50, top, nil); !errors.Is(err, NilSystemErr) {
+		t.Errorf("NewPiecewise with a nil bottom = %v, want NilSystemErr", err)
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, coeff := range []float64{0, 0.5, 1} {
+		for _, exp := range []float64{1, 1.33, 3} {
+			system := newSystem(t, 500, 1000, 100000, coeff, exp)
+			if got := system.Spread(); got != 99000 {
+				t.Errorf("coeff %g, exp %g: Spread() = %g, want scoreMax - scoreMin = 99000", coeff, exp, got)
+			}
+		}
+	}
+
+	capped, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).With(WithScoreCap(50000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	highest, lowest := math.Inf(-1), math.Inf(1)
+	for position := uint(1); position <= 500; position++ {
+		score, _ := capped.Score(position)
+		highest, lowest = max(highest, score), min(lowest, score)
+	}
+
+	if got := capped.Spread(); got != highest-lowest || got != 49000 {
+		t.Errorf("capped Spread() = %g, want %g", got, highest-lowest)
+	}
+}
+
+func TestNewInt(t *testing.T) {
+	system, err := NewInt(500, 1000, 100000, 0.5, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := newSystem(t, 500, 1000, 100000, 0.5, 1.33); !system.Equal(want) {
+		t.Errorf("NewInt = %v, want %v", system, want)
+	}
+
+	if first, ok := system.ScoreInt(1); !ok || first != 100000 {
+		t.Errorf("ScoreInt(1) = %d, %t, want 100000, true", first, ok)
+	}
+
+	if _, err := NewInt(500, 0, 100000, 0.5, 1.33); !errors.Is(err, ScoreMinOutOfRangeErr) {
+		t.Errorf("NewInt with scoreMin 0 = %v, want ScoreMinOutOfRangeErr", err)
+	}
+
+	if _, err := NewInt(500, 1000, 1000, 0.5, 1.33); !errors.Is(err, ScoreMaxOutOfRangeErr) {
+		t.Errorf("NewInt with scoreMax equal to scoreMin = %v, want ScoreMaxOutOfRangeErr", err)
+	}
+}
+
+func TestScoreClamped(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	middle, _ := system.Score(250)
+
+	tests := []struct {
+		name     string
+		position uint
+		lo, hi   float64
+		want     float64
+	}{
+		{"inside", 250, 1000, 100000, middle},
+		{"below", 500, 5000, 50000, 5000},
+		{"above", 1, 5000, 50000, 50000},
+		{"empty window", 250, middle, middle, middle},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, ok := system.ScoreClamped(test.position, test.lo, test.hi); !ok || got != test.want {
+				t.Errorf("ScoreClamped(%d, %g, %g) = %g, %t, want %g, true",
+					test.position, test.lo, test.hi, got, ok, test.want)
+			}
+		})
+	}
+
+	if score, ok := system.ScoreClamped(250, 50000, 5000); ok {
+		t.Errorf("ScoreClamped with lo > hi = %g, true, want false", score)
+	}
+
+	if score, ok := system.ScoreClamped(250, math.NaN(), 5000); ok {
+		t.Errorf("ScoreClamped with NaN lo = %g, true, want false", score)
+	}
+
+	if score, ok := system.ScoreClamped(0, 5000, 50000); ok {
+		t.Errorf("ScoreClamped(0) = %g, true, want false", score)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	reference := system.AppendScores(nil)
+
+	if ok, mismatch := system.Matches(reference, 0); !ok || mismatch != -1 {
+		t.Errorf("Matches(exact) = %t, %d, want true, -1", ok, mismatch)
+	}
+
+	nudged := slices.Clone(reference)
+	nudged[100] += 0.5
+	if ok, mismatch := system.Matches(nudged, 1); !ok || mismatch != -1 {
+		t.Errorf("Matches(within tolerance) = %t, %d, want true, -1", ok, mismatch)
+	}
+
+	if ok, mismatch := system.Matches(nudged, 0.25); ok || mismatch != 100 {
+		t.Errorf("Matches(outside tolerance) = %t, %d, want false, 100", ok, mismatch)
+	}
+
+	if ok, mismatch := system.Matches(reference[:499], 1); ok || mismatch != -1 {
+		t.Errorf("Matches(short reference) = %t, %d, want false, -1", ok, mismatch)
+	}
+}
+
+func TestIsMonotonic(t *testing.T) {
+	if system := newSystem(t, 500, 1000, 100000, 0.5, 1.33); !system.IsMonotonic() {
+		t.Error("IsMonotonic() = false for New, want true")
+	}
+
+	reversed, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).With(WithReversed(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reversed.IsMonotonic() {
+		t.Error("IsMonotonic() = false for a reversed System, want true")
+	}
+
+	// a tent rises to 1 at the middle of the leaderboard and falls back to 0.
+	tent := func(alpha float64) float64 {
+		return 1 - math.Abs((2*alpha)-1)
+	}
+
+	system, err := NewWithEasing(500, 1000, 100000, 0.5, tent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if system.IsMonotonic() {
+		t.Error("IsMonotonic() = true for a non-monotonic easing function, want false")
+	}
+}
+
+func TestBlend(t *testing.T) {
+	// b's wider range, larger coeff and larger back-loading exp each raise its scores above a's, so the blend scores
+	// each position between the two.
+	a := newSystem(t, 500, 1000, 50000, 0.25, 1.33)
+	b := newSystem(t, 500, 2000, 100000, 0.5, 2)
+
+	for _, test := range []struct {
+		t    float64
+		want *System
+	}{{0, a}, {1, b}} {
+		blended, err := Blend(a, b, test.t)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !blended.Equal(test.want) {
+			t.Errorf("Blend(a, b, %g) = %v, want %v", test.t, blended, test.want)
+		}
+	}
+
+	blended, err := Blend(a, b, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for position := uint(1); position <= 500; position++ {
+		aScore, bScore := a.ScoreUnchecked(position), b.ScoreUnchecked(position)
+		if score := blended.ScoreUnchecked(position); score < min(aScore, bScore) || score > max(aScore, bScore) {
+			t.Errorf("Score(%d) = %g, want between %g and %g", position, score, aScore, bScore)
+		}
+	}
+}
+
+func TestBlendOpposingParameters(t *testing.T) {
+	// front-loading makes a larger exp lower the scores, while a larger coeff raises them, so b pulls the curve in both
+	// directions at once.
+	a, err := newSystem(t, 500, 1000, 100000, 0, 1).With(WithExponentMode(ExpFrontLoad))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := newSystem(t, 500, 1000, 100000, 1, 5).With(WithExponentMode(ExpFrontLoad))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blended, err := Blend(a, b, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := newSystem(t, 500, 1000, 100000, 0.5, 3).With(WithExponentMode(ExpFrontLoad))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !blended.Equal(want) {
+		t.Errorf("Blend(a, b, 0.5) = %v, want %v", blended, want)
+	}
+
+	// the parameters are interpolated rather than the scores, so the blend falls outside both here.
+	aScore, bScore, score := a.ScoreUnchecked(3), b.ScoreUnchecked(3), blended.ScoreUnchecked(3)
+	if !(score < min(aScore, bScore)) {
+		t.Errorf("Score(3) = %g, want it below both %g and %g", score, aScore, bScore)
+	}
+}
+
+func TestBlendRejects(t *testing.T) {
+	a := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	reversed, err := a.With(WithReversed(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	explicit, err := NewWithControl(500, 1000, 100000, 60000, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	smaller := newSystem(t, 400, 1000, 100000, 0.5, 1.33)
+	eased, err := NewWithEasing(500, 1000, 100000, 0.5, func(alpha float64) float64 { return alpha })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		a, b *System
+		t    float64
+		err  error
+	}{
+		{"nil a", nil, a, 0.5, NilSystemErr},
+		{"nil b", a, nil, 0.5, NilSystemErr},
+		{"t above 1", a, a, 1.5, BlendWeightOutOfRangeErr},
+		{"t NaN", a, a, math.NaN(), BlendWeightOutOfRangeErr},
+		{"different participantCounts", a, smaller, 0.5, ParticipantCountMismatchErr},
+		{"custom easing function", a, eased, 0.5, BlendUnsupportedErr},
+		{"different kinds of curve", a, explicit, 0.5, BlendKindMismatchErr},
+		{"different options", a, reversed, 0.5, BlendOptionsMismatchErr},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Blend(test.a, test.b, test.t); !errors.Is(err, test.err) {
+				t.Errorf("Blend = %v, want %v", err, test.err)
+			}
+		})
+	}
+
+	var validationErr *ValidationError
+	if _, err := Blend(a, a, -0.5); !errors.As(err, &validationErr) || validationErr.Field != "t" {
+		t.Errorf("Blend with t of -0.5 = %v, want a *ValidationError for t", err)
+	}
+}
+
+func TestScoreSigFig(t *testing.T) {
+	// a linear System scores position 2 at exactly 75000, and position 4 at 25000, which makes the rounding exact.
+	system := newSystem(t, 5, 1, 100001, 0, 1)
+
+	tests := []struct {
+		name     string
+		position uint
+		figs     int
+		want     float64
+	}{
+		{"first", 1, 3, 100000},
+		{"one figure", 2, 1, 80000},
+		{"two figures", 2, 2, 75000},
+		{"more figures than digits", 2, 10, 75001},
+		{"last", 5, 3, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, ok := system.ScoreSigFig(test.position, test.figs); !ok || got != test.want {
+				t.Errorf("ScoreSigFig(%d, %d) = %g, %t, want %g, true", test.position, test.figs, got, ok, test.want)
+			}
+		})
+	}
+
+	small := newSystem(t, 500, 1, 2, 0.5, 1.33)
+	for position := uint(1); position <= 500; position++ {
+		score := small.ScoreUnchecked(position)
+		got, _ := small.ScoreSigFig(position, 3)
+		if math.Abs(got-score) > 0.005 || got != math.Round(got*100)/100 {
+			t.Fatalf("ScoreSigFig(%d, 3) = %g for score %g, want it rounded to 2 decimal places", position, got, score)
+		}
+	}
+
+	if score, ok := system.ScoreSigFig(1, 0); ok {
+		t.Errorf("ScoreSigFig(1, 0) = %g, true, want false", score)
+	}
+
+	if score, ok := system.ScoreSigFig(0, 3); ok {
+		t.Errorf("ScoreSigFig(0, 3) = %g, true, want false", score)
+	}
+}
+
+func TestControlPoint(t *testing.T) {
+	tests := []struct {
+		coeff float64
+		want  float64
+	}{
+		{0, 50500},
+		{0.25, 62875},
+		{0.5, 75250},
+		{1, 100000},
+	}
+
+	for _, test := range tests {
+		system := newSystem(t, 500, 1000, 100000, test.coeff, 1.33)
+		if got := system.ControlPoint(); got != test.want {
+			t.Errorf("coeff %g: ControlPoint() = %g, want %g", test.coeff, got, test.want)
+		}
+	}
+
+	system, err := NewWithControl(500, 1000, 100000, 12345, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := system.ControlPoint(); got != 12345 {
+		t.Errorf("NewWithControl: ControlPoint() = %g, want 12345", got)
+	}
+}
+
+func TestTierThresholds(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	percents := []float64{0.01, 0.05, 0.25, 0.99}
+	thresholds, err := system.TierThresholds(percents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for idx, percent := range percents {
+		if want, _ := system.Percentile(percent); thresholds[idx] != want {
+			t.Errorf("thresholds[%d] = %g, want Percentile(%g) = %g", idx, thresholds[idx], percent, want)
+		}
+	}
+
+	if !slices.IsSorted(thresholds) || len(slices.Compact(slices.Clone(thresholds))) != len(thresholds) {
+		t.Errorf("TierThresholds(%v) = %v, want strictly increasing thresholds", percents, thresholds)
+	}
+
+	for _, percent := range []float64{-0.01, 1.01, math.NaN()} {
+		_, err := system.TierThresholds([]float64{0.5, percent})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "percents[1]" ||
+			!errors.Is(err, PercentOutOfRangeErr) {
+			t.Errorf("TierThresholds with %g = %v, want a *ValidationError for percents[1]", percent, err)
+		}
+	}
+}
+
+func TestMeanInRange(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	scores := system.AppendScores(nil)
+
+	tests := []struct {
+		name       string
+		start, end uint
+	}{
+		{"single position", 42, 42},
+		{"top quartile", 1, 125},
+		{"middle", 200, 300},
+		{"full board", 1, 500},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			total := 0.0
+			for _, score := range scores[test.start-1 : test.end] {
+				total += score
+			}
+
+			want := total / float64(test.end-test.start+1)
+			if got, ok := system.MeanInRange(test.start, test.end); !ok || math.Abs(got-want) > 1e-9*want {
+				t.Errorf("MeanInRange(%d, %d) = %g, %t, want %g, true", test.start, test.end, got, ok, want)
+			}
+		})
+	}
+
+	for _, bounds := range [][2]uint{{0, 10}, {10, 9}, {1, 501}} {
+		if mean, ok := system.MeanInRange(bounds[0], bounds[1]); ok {
+			t.Errorf("MeanInRange(%d, %d) = %g, true, want false", bounds[0], bounds[1], mean)
+		}
+	}
+}
+
+func TestInflectionPosition(t *testing.T) {
+	// both control points sit at the middle of the range, so the S-curve is symmetric about its middle position.
+	cubic, err := NewCubic(501, 1000, 100000, 0, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if position, ok := cubic.InflectionPosition(); !ok || position < 249 || position > 253 {
+		t.Errorf("InflectionPosition() = %d, %t for an S-curve, want about 251, true", position, ok)
+	}
+
+	for _, exp := range []float64{1, 1.33} {
+		system := newSystem(t, 500, 1000, 100000, 0.5, exp)
+		if position, ok := system.InflectionPosition(); ok {
+			t.Errorf("exp %g: InflectionPosition() = %d, true, want false", exp, position)
+		}
+	}
+}
+
+func TestScoreAllAllocs(t *testing.T) {
+	base := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	withOptions, err := base.With(WithReversed(true), WithQuantum(10), WithFloorBelow(400, 2000), WithScoreCap(90000),
+		WithIntegerScores(), WithExponentMode(ExpFrontLoad))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withOptions, err = withOptions.WithOverride(1, 95000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decayed, err := base.With(WithMultiplicativeDecay(0.99))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cubic, err := NewCubic(500, 1000, 100000, 0.25, 0.75, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	piecewise, err := NewPiecewise(500, 100, base, cubic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		system *System
+	}{
+		{"New", base},
+		{"options", withOptions},
+		{"decay", decayed},
+		{"cubic", cubic},
+		{"piecewise", piecewise},
+	}
+
+	buf := make([]float64, 500)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(10, func() { test.system.ScoreAll(buf) }); allocs != 0 {
+				t.Errorf("ScoreAll allocated %g times per run, want 0", allocs)
+			}
+		})
+	}
+}
+
+func TestNewFromPool(t *testing.T) {
+	system, err := NewFromPool(500, 100000, 0.25, 0.01, 0.5, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := newSystem(t, 500, 1000, 25000, 0.5, 1.33); !system.Equal(want) {
+		t.Errorf("NewFromPool = %v, want %v", system, want)
+	}
+
+	tests := []struct {
+		name                  string
+		topShare, bottomShare float64
+		field                 string
+	}{
+		{"top share above 1", 1.5, 0.01, "topShare"},
+		{"top share below 0", -0.25, 0.01, "topShare"},
+		{"bottom share above 1", 0.25, 1.01, "bottomShare"},
+		{"bottom share NaN", 0.25, math.NaN(), "bottomShare"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewFromPool(500, 100000, test.topShare, test.bottomShare, 0.5, 1.33)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != test.field ||
+				!errors.Is(err, ShareOutOfRangeErr) {
+				t.Errorf("NewFromPool = %v, want a *ValidationError for %s", err, test.field)
+			}
+		})
+	}
+
+	if _, err := NewFromPool(500, 100000, 0.25, 0, 0.5, 1.33); !errors.Is(err, ScoreMinOutOfRangeErr) {
+		t.Errorf("NewFromPool with a bottom score of 0 = %v, want ScoreMinOutOfRangeErr", err)
+	}
+}
+
+func TestScoreSet(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	positions := []uint{100, 1, 500, 10}
+	buf := make([]float64, len(positions))
+	if !system.ScoreSet(positions, buf) {
+		t.Fatal("ScoreSet = false, want true")
+	}
+
+	for idx, position := range positions {
+		if want, _ := system.Score(position); buf[idx] != want {
+			t.Errorf("buf[%d] = %g, want Score(%d) = %g", idx, buf[idx], position, want)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		positions []uint
+		buf       []float64
+	}{
+		{"zero position", []uint{1, 0, 10}, make([]float64, 3)},
+		{"position past participantCount", []uint{1, 10, 501}, make([]float64, 3)},
+		{"short buf", []uint{1, 10, 100}, make([]float64, 2)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for idx := range test.buf {
+				test.buf[idx] = -1
+			}
+
+			if system.ScoreSet(test.positions, test.buf) {
+				t.Error("ScoreSet = true, want false")
+			}
+
+			for idx, score := range test.buf {
+				if score != -1 {
+					t.Errorf("buf[%d] = %g, want it untouched", idx, score)
+				}
+			}
+		})
+	}
+}
+
+func TestScoreAllErr(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	buf := make([]float64, 500)
+	if err := system.ScoreAllErr(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := system.AppendScores(nil); !slices.Equal(buf, want) {
+		t.Error("ScoreAllErr scores differ from AppendScores")
+	}
+
+	err := system.ScoreAllErr(make([]float64, 499))
+	if !errors.Is(err, BufferLengthErr) {
+		t.Errorf("ScoreAllErr with a short buf = %v, want BufferLengthErr", err)
+	}
+
+	if err != nil && (!strings.Contains(err.Error(), "499") || !strings.Contains(err.Error(), "500")) {
+		t.Errorf("ScoreAllErr error %q, want it to name both 499 and 500", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		system *System
+		want   Stats
+	}{
+		{"odd", newSystem(t, 5, 1000, 5000, 0, 1), Stats{1000, 5000, 3000, 3000, math.Sqrt(2e6)}},
+		{"even", newSystem(t, 4, 1000, 4000, 0, 1), Stats{1000, 4000, 2500, 2500, math.Sqrt(1.25e6)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the standard deviation is accumulated incrementally, so it may be off by a rounding error.
+			got := test.system.Stats()
+			if got.Min != test.want.Min || got.Max != test.want.Max || got.Mean != test.want.Mean ||
+				got.Median != test.want.Median || math.Abs(got.StdDev-test.want.StdDev) > 1e-9*test.want.StdDev {
+				t.Errorf("Stats() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	// compare against a straightforward two-pass computation for a curved System.
+	system := newSystem(t, 7, 1000, 100000, 0.5, 1.33)
+	scores := system.AppendScores(nil)
+	mean := 0.0
+	for _, score := range scores {
+		mean += score / 7
+	}
+
+	variance := 0.0
+	for _, score := range scores {
+		variance += (score - mean) * (score - mean) / 7
+	}
+
+	stats := system.Stats()
+	if stats.Min != scores[6] || stats.Max != scores[0] || stats.Median != scores[3] {
+		t.Errorf("Stats() = %+v, want Min %g, Max %g and Median %g", stats, scores[6], scores[0], scores[3])
+	}
+
+	if math.Abs(stats.Mean-mean) > 1e-9*mean || math.Abs(stats.StdDev-math.Sqrt(variance)) > 1e-9*mean {
+		t.Errorf("Stats() = %+v, want Mean %g and StdDev %g", stats, mean, math.Sqrt(variance))
+	}
+}
+
+func TestRescale(t *testing.T) {
+	system := newSystem(t, 500, 1000, 5000, 0.5, 1.33)
+
+	tests := []struct {
+		name  string
+		score float64
+		want  float64
+	}{
+		{"scoreMin", 1000, 0},
+		{"scoreMax", 5000, 100},
+		{"midpoint", 3000, 50},
+		{"quarter", 2000, 25},
+		{"extrapolated below", 0, -25},
+		{"extrapolated above", 6000, 125},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := system.Rescale(test.score, 0, 100); got != test.want {
+				t.Errorf("Rescale(%g, 0, 100) = %g, want %g", test.score, got, test.want)
+			}
+		})
+	}
+
+	if got := system.Rescale(1000, 100, 0); got != 100 {
+		t.Errorf("Rescale(1000, 100, 0) = %g, want an inverted range to map scoreMin to 100", got)
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name   string
+		middle float64
+	}{
+		{"coefficient", 60000},
+		{"back-loading exponent", 90000},
+		{"front-loading exponent", 20000},
+		{"linear", 50500},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			system, err := Fit(501, 100000, test.middle, 1000)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, check := range []struct {
+				position uint
+				want     float64
+			}{{1, 100000}, {251, test.middle}, {501, 1000}} {
+				if got := system.ScoreUnchecked(check.position); math.Abs(got-check.want) > 1e-6 {
+					t.Errorf("Score(%d) = %g, want %g", check.position, got, check.want)
+				}
+			}
+		})
+	}
+
+	for _, middle := range []float64{1000, 100000, 500, 100001, math.NaN()} {
+		_, err := Fit(501, 100000, middle, 1000)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "middle" || !errors.Is(err, MiddleOutOfRangeErr) {
+			t.Errorf("Fit with middle %g = %v, want a *ValidationError for middle", middle, err)
+		}
+	}
+
+	if _, err := Fit(501, 1000, 500, 100000); !errors.Is(err, ScoreMaxOutOfRangeErr) {
+		t.Errorf("Fit with first below last = %v, want ScoreMaxOutOfRangeErr", err)
+	}
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
@@ -0,0 +1,214 @@
+package bezierscore
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+)
+
+// Option overrides one of a System's parameters. Options are applied by With.
+type Option func(s *System)
+
+// WithParticipantCount overrides the participantCount.
+func WithParticipantCount(participantCount uint) Option {
+	return func(s *System) {
+		s.participantCount = participantCount
+	}
+}
+
+// WithScoreRange overrides both scoreMin and scoreMax.
+func WithScoreRange(scoreMin, scoreMax float64) Option {
+	return func(s *System) {
+		s.lowerBound = scoreMin
+		s.upperBound = scoreMax
+	}
+}
+
+// WithCoefficient overrides the control coefficient. For cubic Systems, this is the coefficient of the first control
+// point. For Systems constructed with NewWithControl, the control point is derived from coeff from then on.
+func WithCoefficient(coeff float64) Option {
+	return func(s *System) {
+		s.controlCoefficient = coeff
+		s.explicitControl = false
+	}
+}
+
+// WithExponent overrides the exponent. For Systems constructed with NewWithEasing, alpha is warped by exp from then on.
+func WithExponent(exp float64) Option {
+	return func(s *System) {
+		s.exponent = exp
+		s.ease = nil
+	}
+}
+
+// WithReversed sets whether the leaderboard is reversed. A reversed System awards scoreMin to first place and scoreMax
+// to last place, instead of the other way around.
+func WithReversed(reversed bool) Option {
+	return func(s *System) {
+		s.reversed = reversed
+	}
+}
+
+// WithQuantum snaps every score to the nearest multiple of step, for currencies that only exist in fixed increments.
+// This applies to Score and everything built on it, like ScoreAll, ScoreInt and TotalScore. A step of 0 disables
+// quantization, and step must not be negative.
+func WithQuantum(step float64) Option {
+	return func(s *System) {
+		s.quantum = step
+	}
+}
+
+// WithRoundedControl sets whether computed control points are rounded to the nearest integer, or to the nearest
+// multiple of the quantum set by WithQuantum, when the System is constructed.
+//
+// A round control point keeps Score exactly consistent with tables precomputed elsewhere from the same integer
+// control point, at the cost of shifting the curve slightly away from the shape its coefficient describes. The shift
+// is at most half a step, so it is negligible for wide score ranges but can be noticeable for narrow ones. Control
+// points given explicitly to NewWithControl are never rounded.
+func WithRoundedControl(rounded bool) Option {
+	return func(s *System) {
+		s.roundControl = rounded
+	}
+}
+
+// WithFloorBelow awards score to every position worse than position, such as a fixed participation score, while
+// position and better positions keep scoring along the curve. position must be less than participantCount, so that at
+// least last place is floored, and score must be between scoreMin and scoreMax inclusive. A position of 0 disables the
+// floor.
+//
+// Like WithQuantum, this applies to Score and everything built on it. Slope and Curvature describe the curve alone.
+func WithFloorBelow(position uint, score float64) Option {
+	return func(s *System) {
+		s.floorPosition = position
+		s.floorScore = score
+	}
+}
+
+// WithScoreCap limits every score to at most scoreCap, such as the largest prize a pool can pay out, while scores
+// below it are unaffected. The cap is applied after quantization, so it is never exceeded. A scoreCap of 0 disables the
+// cap, and scoreCap must not be negative.
+func WithScoreCap(scoreCap float64) Option {
+	return func(s *System) {
+		s.scoreCap = scoreCap
+	}
+}
+
+// WithIntegerScores rounds every score half away from zero to the nearest integer, so that Score and everything built
+// on it, like ScoreAll and TotalScore, only ever produce whole numbers while still returning float64.
+//
+// Rounding happens after quantization, so a whole-number quantum from WithQuantum is preserved, while a fractional one
+// is rounded again to the nearest integer. A cap from WithScoreCap is rounded down, so that it stays whole.
+func WithIntegerScores() Option {
+	return func(s *System) {
+		s.integerScores = true
+	}
+}
+
+// WithExponentMode selects how the exponent warps alpha. It has no effect on Systems with an easing function from
+// NewWithEasing, or with WithMultiplicativeDecay.
+func WithExponentMode(mode ExponentMode) Option {
+	return func(s *System) {
+		s.exponentMode = mode
+	}
+}
+
+// WithMultiplicativeDecay replaces the bezier curve with a geometric one, where each position scores rate times the
+// position above it: position scores scoreMax * rate^(position-1), but never less than scoreMin. rate must be at least
+// 0 and less than 1, and a rate of 0 restores the bezier curve.
+//
+// The decay takes the place of every option that shapes the curve, including the coefficients, exponent, easing
+// function and piecewise Systems. WithReversed still mirrors the leaderboard, and output options like WithQuantum,
+// WithFloorBelow and WithScoreCap still apply to the decayed scores. The decay itself is computed in float64, even by
+// ScoreBig.
+func WithMultiplicativeDecay(rate float64) Option {
+	return func(s *System) {
+		s.decayRate = rate
+	}
+}
+
+// WithLogger sets a logger that receives debug records when the System is constructed by With, and when Score is
+// called with an invalid position. A nil logger, the default, disables logging entirely.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *System) {
+		s.logger = logger
+	}
+}
+
+// With returns a new System that starts from the receiver's parameters and applies each of opts in order. The
+// receiver is never modified.
+//
+// The resulting parameters are validated after all opts have been applied, the same way New validates them.
+//
+// example:
+//
+//	larger, err := system.With(bezierscore.WithParticipantCount(1000))
+func (s *System) With(opts ...Option) (*System, error) {
+	clone := *s
+	for _, opt := range opts {
+		opt(&clone)
+	}
+
+	if err := clone.Validate(); err != nil {
+		return nil, err
+	}
+
+	clone.prepare()
+	if clone.logger != nil {
+		clone.logger.LogAttrs(
+			context.Background(),
+			slog.LevelDebug,
+			"bezierscore: constructed System",
+			slog.Uint64("participantCount", uint64(clone.participantCount)),
+			slog.Float64("scoreMin", clone.lowerBound),
+			slog.Float64("scoreMax", clone.upperBound),
+		)
+	}
+
+	return &clone, nil
+}
+
+// Resize returns a new System with participantCount set to newCount, and every other parameter and option identical to
+// the receiver. It is shorthand for With(WithParticipantCount(newCount)).
+func (s *System) Resize(newCount uint) (*System, error) {
+	return s.With(WithParticipantCount(newCount))
+}
+
+// WithOverride returns a new System that awards score to position in place of the curve, such as a fixed prize for
+// first place, while every other position keeps its score. Overrides accumulate, so calling WithOverride on the result
+// adds another, and overriding the same position again replaces its score. The receiver is never modified.
+//
+// position must be between 1 and participantCount inclusive, and score between scoreMin and scoreMax inclusive. Output
+// options like WithQuantum and WithScoreCap still apply to overridden scores.
+//
+// An override need not fit between the scores of its neighbours, so a System with overrides may award a worse position
+// more than a better one. Rank, LastPositionAbove and the Rank of a Table therefore scan every position of such a
+// System, rather than binary searching them.
+func (s *System) WithOverride(position uint, score float64) (*System, error) {
+	overrides := maps.Clone(s.overrides)
+	if overrides == nil {
+		overrides = make(map[uint]float64, 1)
+	}
+
+	overrides[position] = score
+	return s.With(func(clone *System) {
+		clone.overrides = overrides
+	})
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
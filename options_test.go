@@ -0,0 +1,727 @@
+package bezierscore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestWithOverridesOneField(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	tests := []struct {
+		name   string
+		option Option
+		want   *System
+	}{
+		{"participant count", WithParticipantCount(100), newSystem(t, 100, 1000, 100000, 0.5, 1.33)},
+		{"score range", WithScoreRange(10, 500), newSystem(t, 500, 10, 500, 0.5, 1.33)},
+		{"coefficient", WithCoefficient(0.9), newSystem(t, 500, 1000, 100000, 0.9, 1.33)},
+		{"exponent", WithExponent(2), newSystem(t, 500, 1000, 100000, 0.5, 2)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := system.With(test.option)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !got.Equal(test.want) {
+				t.Errorf("With = %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	if want := newSystem(t, 500, 1000, 100000, 0.5, 1.33); !system.Equal(want) {
+		t.Errorf("With modified the receiver to %v", system)
+	}
+}
+
+func TestWithValidatesAfterAllOptions(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	// the intermediate range is invalid after the first option, but the second corrects it.
+	got, err := system.With(WithScoreRange(200000, 100000), WithScoreRange(1000, 200000))
+	if err != nil {
+		t.Fatalf("With = %v, want the final options to be valid", err)
+	}
+
+	if got.ScoreMax() != 200000 {
+		t.Errorf("ScoreMax() = %g, want 200000", got.ScoreMax())
+	}
+
+	if _, err := system.With(WithCoefficient(2)); !errors.Is(err, CoefficientOutOfRangeErr) {
+		t.Errorf("With(WithCoefficient(2)) = %v, want CoefficientOutOfRangeErr", err)
+	}
+}
+
+func TestWithReversed(t *testing.T) {
+	system, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).With(WithReversed(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first, _ := system.Score(1); first != 1000 {
+		t.Errorf("Score(1) = %g, want scoreMin 1000", first)
+	}
+
+	if last, _ := system.Score(500); last != 100000 {
+		t.Errorf("Score(500) = %g, want scoreMax 100000", last)
+	}
+
+	buf := make([]float64, 500)
+	system.ScoreAll(buf)
+	if !slices.IsSorted(buf) {
+		t.Error("reversed ScoreAll is not non-decreasing")
+	}
+
+	for idx, score := range buf {
+		position := uint(idx) + 1
+		if want, _ := system.Score(position); score != want {
+			t.Fatalf("ScoreAll()[%d] = %g, want Score(%d) = %g", idx, score, position, want)
+		}
+
+		if got, ok := system.Rank(score); !ok || got != position {
+			t.Fatalf("Rank(%g) = %d, %t, want %d, true", score, got, ok, position)
+		}
+	}
+}
+
+func TestWithReversedMirrorsScores(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	reversed, err := system.With(WithReversed(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for position := uint(1); position <= 500; position++ {
+		want, _ := system.Score(501 - position)
+		if got, _ := reversed.Score(position); got != want {
+			t.Fatalf("reversed Score(%d) = %g, want Score(%d) = %g", position, got, 501-position, want)
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for _, newCount := range []uint{2, 100, 5000} {
+		resized, err := system.Resize(newCount)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := newSystem(t, newCount, 1000, 100000, 0.5, 1.33); !resized.Equal(want) {
+			t.Errorf("Resize(%d) = %v, want %v", newCount, resized, want)
+		}
+
+		if first, _ := resized.Score(1); first != 100000 {
+			t.Errorf("Resize(%d).Score(1) = %g, want 100000", newCount, first)
+		}
+
+		if last, _ := resized.Score(newCount); last != 1000 {
+			t.Errorf("Resize(%d).Score(%d) = %g, want 1000", newCount, newCount, last)
+		}
+	}
+
+	if system.ParticipantCount() != 500 {
+		t.Errorf("Resize modified the receiver to %v", system)
+	}
+
+	if _, err := system.Resize(1); !errors.Is(err, ParticipantCountOutOfRangeErr) {
+		t.Errorf("Resize(1) = %v, want ParticipantCountOutOfRangeErr", err)
+	}
+}
+
+func TestWithQuantum(t *testing.T) {
+	system, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).With(WithQuantum(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]float64, 500)
+	system.ScoreAll(buf)
+
+	total := 0.0
+	for idx, score := range buf {
+		position := uint(idx) + 1
+		if math.Mod(score, 50) != 0 {
+			t.Fatalf("ScoreAll()[%d] = %g, want a multiple of 50", idx, score)
+		}
+
+		if want, _ := system.Score(position); score != want {
+			t.Fatalf("ScoreAll()[%d] = %g, want Score(%d) = %g", idx, score, position, want)
+		}
+
+		if got, _ := system.ScoreInt(position); float64(got) != score {
+			t.Fatalf("ScoreInt(%d) = %d, want %g", position, got, score)
+		}
+
+		total += score
+	}
+
+	if got := system.TotalScore(); got != total {
+		t.Errorf("TotalScore() = %g, want %g", got, total)
+	}
+
+	if first, _ := system.Score(1); first != 100000 {
+		t.Errorf("Score(1) = %g, want 100000", first)
+	}
+
+	if last, _ := system.Score(500); last != 1000 {
+		t.Errorf("Score(500) = %g, want 1000", last)
+	}
+}
+
+func TestWithQuantumSnapsToNearest(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	quantized, err := system.With(WithQuantum(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for position := uint(1); position <= 500; position++ {
+		raw, _ := system.Score(position)
+		if got, _ := quantized.Score(position); math.Abs(got-raw) > 25 {
+			t.Fatalf("Score(%d) = %g, want within 25 of %g", position, got, raw)
+		}
+	}
+
+	disabled, err := quantized.With(WithQuantum(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !disabled.Equal(system) {
+		t.Errorf("WithQuantum(0) = %v, want quantization disabled", disabled)
+	}
+
+	if _, err := system.With(WithQuantum(-1)); !errors.Is(err, QuantumOutOfRangeErr) {
+		t.Errorf("WithQuantum(-1) = %v, want QuantumOutOfRangeErr", err)
+	}
+}
+
+func TestWithRoundedControl(t *testing.T) {
+	// the computed control point is 0.7*50500.5 + 0.3*100001, about 65350.65.
+	system := newSystem(t, 500, 1000, 100001, 0.3, 1.33)
+
+	tests := []struct {
+		name    string
+		options []Option
+		want    float64
+	}{
+		{"integer", []Option{WithRoundedControl(true)}, 65351},
+		{"quantum", []Option{WithRoundedControl(true), WithQuantum(50)}, 65350},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rounded, err := system.With(test.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := rounded.ControlPoint(); got != test.want {
+				t.Errorf("ControlPoint() = %g, want %g", got, test.want)
+			}
+
+			if !rounded.IsMonotonic() {
+				t.Error("IsMonotonic() = false, want true")
+			}
+		})
+	}
+
+	unrounded, err := system.With(WithRoundedControl(true), WithRoundedControl(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := unrounded.ControlPoint(); got != system.ControlPoint() {
+		t.Errorf("ControlPoint() = %g after disabling rounding, want %g", got, system.ControlPoint())
+	}
+}
+
+// captureHandler is a slog.Handler that records every record it handles.
+type captureHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *captureHandler) Handle(_ context.Context, record slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, record)
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *captureHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// attrs collects the attributes of record by key.
+func attrs(record slog.Record) map[string]slog.Value {
+	values := map[string]slog.Value{}
+	record.Attrs(func(attr slog.Attr) bool {
+		values[attr.Key] = attr.Value
+		return true
+	})
+
+	return values
+}
+
+func TestWithLogger(t *testing.T) {
+	handler := &captureHandler{}
+	system, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).With(WithLogger(slog.New(handler)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	system.Score(10)
+	system.Score(501)
+
+	if len(handler.records) != 2 {
+		t.Fatalf("logged %d records, want 2", len(handler.records))
+	}
+
+	constructed := handler.records[0]
+	if constructed.Level != slog.LevelDebug || constructed.Message != "bezierscore: constructed System" {
+		t.Errorf("first record = %v %q, want a construction record", constructed.Level, constructed.Message)
+	}
+
+	if count := attrs(constructed)["participantCount"]; count.Uint64() != 500 {
+		t.Errorf("constructed participantCount = %v, want 500", count)
+	}
+
+	invalid := handler.records[1]
+	if invalid.Level != slog.LevelDebug || invalid.Message != "bezierscore: invalid position" {
+		t.Errorf("second record = %v %q, want an invalid position record", invalid.Level, invalid.Message)
+	}
+
+	values := attrs(invalid)
+	if values["position"].Uint64() != 501 || values["participantCount"].Uint64() != 500 {
+		t.Errorf("invalid position attributes = %v, want position 501 and participantCount 500", values)
+	}
+}
+
+func TestWithoutLoggerDoesNotAllocate(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	if allocs := testing.AllocsPerRun(100, func() { system.Score(501) }); allocs != 0 {
+		t.Errorf("Score of an invalid position allocated %g times without a logger, want 0", allocs)
+	}
+}
+
+func TestWithFloorBelow(t *testing.T) {
+	base := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	system, err := base.With(WithFloorBelow(100, 2500))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for position := uint(1); position <= 100; position++ {
+		if got, want := system.ScoreUnchecked(position), base.ScoreUnchecked(position); got != want {
+			t.Errorf("Score(%d) = %g, want the curve's %g", position, got, want)
+		}
+	}
+
+	for position := uint(101); position <= 500; position++ {
+		if got := system.ScoreUnchecked(position); got != 2500 {
+			t.Errorf("Score(%d) = %g, want the floor of 2500", position, got)
+		}
+	}
+
+	for _, score := range []float64{999, 100001} {
+		var validationErr *ValidationError
+		if _, err := base.With(WithFloorBelow(100, score)); !errors.As(err, &validationErr) ||
+			!errors.Is(err, FloorScoreOutOfRangeErr) {
+			t.Errorf("WithFloorBelow(100, %g) = %v, want FloorScoreOutOfRangeErr", score, err)
+		}
+	}
+
+	for _, position := range []uint{500, 1000} {
+		var validationErr *ValidationError
+		if _, err := base.With(WithFloorBelow(position, 2000)); !errors.As(err, &validationErr) ||
+			validationErr.Field != "floorPosition" || !errors.Is(err, FloorPositionOutOfRangeErr) {
+			t.Errorf("WithFloorBelow(%d, 2000) = %v, want FloorPositionOutOfRangeErr", position, err)
+		}
+	}
+
+	if _, err := system.Resize(100); !errors.Is(err, FloorPositionOutOfRangeErr) {
+		t.Errorf("Resize(100) below the floor position = %v, want FloorPositionOutOfRangeErr", err)
+	}
+
+	if _, err := base.With(WithFloorBelow(0, 0)); err != nil {
+		t.Errorf("WithFloorBelow(0, 0) = %v, want it to disable the floor", err)
+	}
+}
+
+func TestWithScoreCap(t *testing.T) {
+	base := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	system, err := base.With(WithScoreCap(50000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	capped := 0
+	for position := uint(1); position <= 500; position++ {
+		got, curve := system.ScoreUnchecked(position), base.ScoreUnchecked(position)
+		switch {
+		case curve > 50000 && got != 50000:
+			t.Errorf("Score(%d) = %g, want it capped at 50000", position, got)
+		case curve <= 50000 && got != curve:
+			t.Errorf("Score(%d) = %g, want the curve's %g", position, got, curve)
+		case curve > 50000:
+			capped++
+		}
+	}
+
+	if capped == 0 || capped == 500 {
+		t.Errorf("%d positions were capped, want only the top ranks", capped)
+	}
+
+	quantized, err := base.With(WithScoreCap(50001), WithQuantum(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first := quantized.ScoreUnchecked(1); first != 50001 {
+		t.Errorf("Score(1) with a quantum = %g, want the cap of 50001", first)
+	}
+
+	if _, err := base.With(WithScoreCap(-1)); !errors.Is(err, ScoreCapOutOfRangeErr) {
+		t.Errorf("WithScoreCap(-1) = %v, want ScoreCapOutOfRangeErr", err)
+	}
+}
+
+func TestWithMultiplicativeDecay(t *testing.T) {
+	system, err := newSystem(t, 500, 1000, 100000, 0.5, 1.33).With(WithMultiplicativeDecay(0.9))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first := system.ScoreUnchecked(1); first != 100000 {
+		t.Errorf("Score(1) = %g, want scoreMax", first)
+	}
+
+	clamped := false
+	for position := uint(2); position <= 500; position++ {
+		previous, score := system.ScoreUnchecked(position-1), system.ScoreUnchecked(position)
+		if previous*0.9 <= 1000 {
+			clamped = true
+			if score != 1000 {
+				t.Errorf("Score(%d) = %g, want it clamped at scoreMin", position, score)
+			}
+
+			continue
+		}
+
+		if ratio := score / previous; math.Abs(ratio-0.9) > 1e-12 {
+			t.Errorf("Score(%d) / Score(%d) = %g, want 0.9", position, position-1, ratio)
+		}
+	}
+
+	if !clamped {
+		t.Error("no position was clamped at scoreMin")
+	}
+
+	for _, rate := range []float64{-0.1, 1} {
+		if _, err := system.With(WithMultiplicativeDecay(rate)); !errors.Is(err, DecayRateOutOfRangeErr) {
+			t.Errorf("WithMultiplicativeDecay(%g) = %v, want DecayRateOutOfRangeErr", rate, err)
+		}
+	}
+
+	var validationErr *ValidationError
+	_, err = system.With(WithMultiplicativeDecay(math.NaN()))
+	if !errors.As(err, &validationErr) || validationErr.Field != "decayRate" || !errors.Is(err, NonFiniteParameterErr) {
+		t.Errorf("WithMultiplicativeDecay(NaN) = %v, want a *ValidationError for decayRate", err)
+	}
+
+	if want := "parameters must be finite, got decayRate=NaN"; err != nil && err.Error() != want {
+		t.Errorf("WithMultiplicativeDecay(NaN) error %q, want %q", err, want)
+	}
+}
+
+func TestWithExponentMode(t *testing.T) {
+	// a coeff of 0 puts the control point at the middle of the range, so the curve is linear in alpha and the scores
+	// mirror exactly as alpha does.
+	backLoaded := newSystem(t, 500, 1000, 100000, 0, 2)
+	frontLoaded, err := backLoaded.With(WithExponentMode(ExpFrontLoad))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for position := uint(1); position <= 500; position++ {
+		front, _ := frontLoaded.Alpha(position)
+		back, _ := backLoaded.Alpha(501 - position)
+		if math.Abs(front-(1-back)) > 1e-12 {
+			t.Errorf("front-loaded Alpha(%d) = %g, want 1 - back-loaded Alpha(%d) = %g",
+				position, front, 501-position, 1-back)
+		}
+
+		frontScore := frontLoaded.ScoreUnchecked(position)
+		backScore := backLoaded.ScoreUnchecked(501 - position)
+		if math.Abs((frontScore-1000)-(100000-backScore)) > 1e-6 {
+			t.Errorf("front-loaded Score(%d) = %g, want the mirror of back-loaded Score(%d) = %g",
+				position, frontScore, 501-position, backScore)
+		}
+	}
+
+	// back-loading keeps the top positions close to scoreMax, while front-loading drops them away from it sooner.
+	if back, front := backLoaded.ScoreUnchecked(50), frontLoaded.ScoreUnchecked(50); !(front < back) {
+		t.Errorf("Score(50) = %g front-loaded, %g back-loaded, want front-loaded lower", front, back)
+	}
+
+	if _, err := backLoaded.With(WithExponentMode(ExpFrontLoad + 1)); !errors.Is(err, ExponentModeOutOfRangeErr) {
+		t.Errorf("WithExponentMode(%d) = %v, want ExponentModeOutOfRangeErr", ExpFrontLoad+1, err)
+	}
+}
+
+func TestWithIntegerScores(t *testing.T) {
+	base := newSystem(t, 500, 1000.5, 100000.5, 0.5, 1.33)
+	system, err := base.With(WithIntegerScores())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scores := system.AppendScores(nil)
+	for idx, score := range scores {
+		if score != math.Trunc(score) {
+			t.Errorf("Score(%d) = %g, want a whole number", idx+1, score)
+		}
+
+		if want := math.Round(base.ScoreUnchecked(uint(idx) + 1)); score != want {
+			t.Errorf("Score(%d) = %g, want %g", idx+1, score, want)
+		}
+	}
+
+	if total := system.TotalScore(); total != math.Trunc(total) {
+		t.Errorf("TotalScore() = %g, want a whole number", total)
+	}
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		check func(score float64) bool
+	}{
+		{
+			"whole quantum",
+			[]Option{WithQuantum(100)},
+			func(score float64) bool { return math.Mod(score, 100) == 0 },
+		},
+		{
+			"fractional quantum",
+			[]Option{WithQuantum(2.5)},
+			func(score float64) bool { return score == math.Trunc(score) },
+		},
+		{
+			"fractional cap",
+			[]Option{WithScoreCap(50000.7)},
+			func(score float64) bool { return score == math.Trunc(score) && score <= 50000 },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			combined, err := system.With(test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for position := uint(1); position <= 500; position++ {
+				if score := combined.ScoreUnchecked(position); !test.check(score) {
+					t.Errorf("Score(%d) = %g", position, score)
+				}
+			}
+		})
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	base := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for _, step := range []float64{0, 0.001} {
+		fine, err := base.With(WithQuantum(step))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if collisions := fine.Collisions(); len(collisions) != 0 {
+			t.Errorf("quantum %g: Collisions() = %v, want none", step, collisions)
+		}
+	}
+
+	coarse, err := base.With(WithQuantum(10000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collisions := coarse.Collisions()
+	if len(collisions) == 0 {
+		t.Fatal("Collisions() is empty with a quantum of 10000, want groups")
+	}
+
+	grouped := 0
+	for idx, group := range collisions {
+		if len(group) < 2 || !slices.IsSorted(group) {
+			t.Errorf("collisions[%d] = %v, want at least two ascending positions", idx, group)
+		}
+
+		if idx > 0 && group[0] <= collisions[idx-1][0] {
+			t.Errorf("collisions[%d] starts at %d, want it after collisions[%d]", idx, group[0], idx-1)
+		}
+
+		for _, position := range group {
+			if score, want := coarse.ScoreUnchecked(position), coarse.ScoreUnchecked(group[0]); score != want {
+				t.Errorf("collisions[%d]: Score(%d) = %g, want %g", idx, position, score, want)
+			}
+		}
+
+		grouped += len(group)
+	}
+
+	// every score is a multiple of 10000 between 0 and 100000, so only a handful of positions can score uniquely.
+	if grouped < 500-11 {
+		t.Errorf("Collisions() grouped %d positions, want at least %d", grouped, 500-11)
+	}
+
+	floored, err := base.With(WithFloorBelow(100, 2000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collisions = floored.Collisions()
+	if len(collisions) != 1 || collisions[0][0] != 101 || len(collisions[0]) != 400 {
+		t.Errorf("Collisions() with a floor below 100 = %d groups, want positions 101 through 500", len(collisions))
+	}
+}
+
+func TestWithOverride(t *testing.T) {
+	base := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	system, err := base.WithOverride(1, 75000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	system, err = system.WithOverride(10, 90000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overrides := map[uint]float64{1: 75000, 10: 90000}
+	for position := uint(1); position <= 500; position++ {
+		want, found := overrides[position]
+		if !found {
+			want = base.ScoreUnchecked(position)
+		}
+
+		if got := system.ScoreUnchecked(position); got != want {
+			t.Errorf("Score(%d) = %g, want %g", position, got, want)
+		}
+	}
+
+	replaced, err := system.WithOverride(10, 80000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := replaced.ScoreUnchecked(10); got != 80000 {
+		t.Errorf("Score(10) after overriding it again = %g, want 80000", got)
+	}
+
+	if got := system.ScoreUnchecked(10); got != 90000 {
+		t.Errorf("WithOverride modified its receiver's Score(10) to %g", got)
+	}
+
+	tests := []struct {
+		name     string
+		position uint
+		score    float64
+		err      error
+	}{
+		{"position 0", 0, 50000, OverridePositionOutOfRangeErr},
+		{"position past participantCount", 501, 50000, OverridePositionOutOfRangeErr},
+		{"score below scoreMin", 10, 999, OverrideScoreOutOfRangeErr},
+		{"score above scoreMax", 10, 100001, OverrideScoreOutOfRangeErr},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := base.WithOverride(test.position, test.score); !errors.Is(err, test.err) {
+				t.Errorf("WithOverride(%d, %g) = %v, want %v", test.position, test.score, err, test.err)
+			}
+		})
+	}
+}
+
+func TestWithOverrideOutOfOrder(t *testing.T) {
+	// position 5 outscores positions 2 through 4, so the scores are no longer ordered.
+	system, err := newSystem(t, 10, 1000, 100000, 0.5, 1.33).WithOverride(5, 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := system.Precompute()
+	for position := uint(1); position <= 10; position++ {
+		// position 5 ties with first place, and ties go to the better position.
+		want := position
+		if position == 5 {
+			want = 1
+		}
+
+		score := system.ScoreUnchecked(position)
+		if got, ok := system.Rank(score); !ok || got != want {
+			t.Errorf("Rank(Score(%d)) = %d, %t, want %d, true", position, got, ok, want)
+		}
+
+		if got, ok := table.Rank(score); !ok || got != want {
+			t.Errorf("Table.Rank(Score(%d)) = %d, %t, want %d, true", position, got, ok, want)
+		}
+	}
+
+	if position, ok := system.LastPositionAbove(99999); !ok || position != 5 {
+		t.Errorf("LastPositionAbove(99999) = %d, %t, want 5, true", position, ok)
+	}
+
+	if position, ok := system.LastPositionAbove(100001); ok {
+		t.Errorf("LastPositionAbove(100001) = %d, true, want false", position)
+	}
+
+	for _, score := range []float64{999, 100001, math.NaN()} {
+		if position, ok := system.Rank(score); ok {
+			t.Errorf("Rank(%g) = %d, true, want false", score, position)
+		}
+
+		if position, ok := table.Rank(score); ok {
+			t.Errorf("Table.Rank(%g) = %d, true, want false", score, position)
+		}
+	}
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
@@ -0,0 +1,63 @@
+package bezierscore
+
+import "sync"
+
+// Streamer scores the participants of a live leaderboard as they finish, one at a time, using their order of arrival
+// as their position. Its capacity is the participantCount of the System it scores with, so a System sized for the
+// largest expected field can be used even when the final number of finishers is not known in advance.
+//
+// A Streamer is safe for concurrent use.
+type Streamer struct {
+	system *System
+
+	mu       sync.Mutex
+	finished map[string]uint
+}
+
+// NewStreamer returns a Streamer that scores finishers along system's curve.
+func NewStreamer(system *System) *Streamer {
+	return &Streamer{
+		system:   system,
+		finished: make(map[string]uint),
+	}
+}
+
+// Finish records playerID as the next finisher, returning their position and the score the System awards to it. The
+// first call returns position 1, the second position 2, and so on. Calling Finish again for a player who has already
+// finished returns their original position and score.
+//
+// Once participantCount players have finished, Finish returns a position and score of 0 for any new player.
+func (s *Streamer) Finish(playerID string) (rank uint, score float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rank, found := s.finished[playerID]
+	if !found {
+		if uint(len(s.finished)) >= s.system.participantCount {
+			return 0, 0
+		}
+
+		rank = uint(len(s.finished)) + 1
+		s.finished[playerID] = rank
+	}
+
+	return rank, s.system.ScoreUnchecked(rank)
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
@@ -0,0 +1,66 @@
+package bezierscore
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestStreamerMatchesScore(t *testing.T) {
+	system := newSystem(t, 5, 1000, 100000, 0.5, 1.33)
+	streamer := NewStreamer(system)
+
+	for position := uint(1); position <= 5; position++ {
+		want, _ := system.Score(position)
+		if rank, score := streamer.Finish("player" + strconv.Itoa(int(position))); rank != position || score != want {
+			t.Errorf("Finish #%d = %d, %g, want %d, %g", position, rank, score, position, want)
+		}
+	}
+}
+
+func TestStreamerRepeatFinisher(t *testing.T) {
+	system := newSystem(t, 5, 1000, 100000, 0.5, 1.33)
+	streamer := NewStreamer(system)
+
+	firstRank, firstScore := streamer.Finish("alice")
+	streamer.Finish("bob")
+	if rank, score := streamer.Finish("alice"); rank != firstRank || score != firstScore {
+		t.Errorf("repeated Finish = %d, %g, want %d, %g", rank, score, firstRank, firstScore)
+	}
+
+	if rank, _ := streamer.Finish("carol"); rank != 3 {
+		t.Errorf("Finish after a repeat = %d, want 3", rank)
+	}
+}
+
+func TestStreamerCapacity(t *testing.T) {
+	system := newSystem(t, 2, 1000, 100000, 0.5, 1.33)
+	streamer := NewStreamer(system)
+	streamer.Finish("alice")
+	streamer.Finish("bob")
+
+	if rank, score := streamer.Finish("carol"); rank != 0 || score != 0 {
+		t.Errorf("Finish beyond capacity = %d, %g, want 0, 0", rank, score)
+	}
+
+	if rank, _ := streamer.Finish("bob"); rank != 2 {
+		t.Errorf("repeated Finish at capacity = %d, want 2", rank)
+	}
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
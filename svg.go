@@ -0,0 +1,85 @@
+package bezierscore
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SVGPath returns SVG path data tracing the score of every position, fitted to a width by height box with its origin
+// at the top left. Positions are spread evenly from x=0 for first place to x=width for last place, and scores are
+// scaled so that MaxScore sits at y=0 and MinScore sits at y=height.
+//
+// example:
+//
+//	d := system.SVGPath(640, 480)
+//	svg := `<svg viewBox="0 0 640 480"><path fill="none" stroke="black" d="` + d + `"/></svg>`
+func (s *System) SVGPath(width, height float64) string {
+	lowest, highest := s.MinScore(), s.MaxScore()
+	spread := highest - lowest
+
+	var builder strings.Builder
+	var buf []byte
+	for position := uint(1); position <= s.participantCount; position++ {
+		score, _ := s.Score(position)
+
+		x := width * float64(position-1) / float64(s.participantCount-1)
+		y := 0.0
+		if spread > 0 {
+			y = height * (highest - score) / spread
+		}
+
+		if position == 1 {
+			builder.WriteString("M")
+		} else {
+			builder.WriteString(" L")
+		}
+
+		buf = strconv.AppendFloat(buf[:0], x, 'f', -1, 64)
+		buf = append(buf, ' ')
+		buf = strconv.AppendFloat(buf, y, 'f', -1, 64)
+		builder.Write(buf)
+	}
+
+	return builder.String()
+}
+
+// Sample is a point on the continuous score curve, as returned by Samples. X is the fraction of the way from first
+// place to last place, in [0, 1], and Y is the score there.
+type Sample = struct{ X, Y float64 }
+
+// Samples returns n points spread evenly along the score curve, from first place at X=0 to last place at X=1. Unlike
+// SVGPath, which traces every position, this lets plots choose their own resolution independently of
+// participantCount. Scores between positions are computed the same way as ScoreAt.
+//
+// n must be at least 2, otherwise SampleCountOutOfRangeErr is returned.
+func (s *System) Samples(n int) ([]Sample, error) {
+	if n < 2 {
+		return nil, SampleCountOutOfRangeErr
+	}
+
+	samples := make([]Sample, n)
+	for idx := range samples {
+		x := float64(idx) / float64(n-1)
+		samples[idx] = Sample{X: x, Y: s.scoreAt(x, 1)}
+	}
+
+	return samples, nil
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
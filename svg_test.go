@@ -0,0 +1,104 @@
+package bezierscore
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSVGPath(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	path := system.SVGPath(640, 480)
+	if !strings.HasPrefix(path, "M") {
+		t.Fatalf("SVGPath = %q, want it to start with M", path[:min(len(path), 20)])
+	}
+
+	commands := strings.Split(path, " L")
+	if len(commands) != 500 {
+		t.Fatalf("SVGPath has %d points, want 500", len(commands))
+	}
+
+	for idx, command := range commands {
+		coords := strings.Fields(strings.TrimPrefix(command, "M"))
+		if len(coords) != 2 {
+			t.Fatalf("point %d = %q, want two coordinates", idx, command)
+		}
+
+		x, err := strconv.ParseFloat(coords[0], 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		y, err := strconv.ParseFloat(coords[1], 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !(x >= 0 && x <= 640 && y >= 0 && y <= 480) {
+			t.Errorf("point %d = (%g, %g), want within 640x480", idx, x, y)
+		}
+	}
+
+	if want := "M0 0"; commands[0] != want {
+		t.Errorf("first point = %q, want %q", commands[0], want)
+	}
+
+	if want := "640 480"; commands[499] != want {
+		t.Errorf("last point = %q, want %q", commands[499], want)
+	}
+}
+
+func TestSamples(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	for _, n := range []int{2, 3, 1000} {
+		samples, err := system.Samples(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(samples) != n {
+			t.Fatalf("Samples(%d) returned %d samples", n, len(samples))
+		}
+
+		first, last := samples[0], samples[n-1]
+		if first.X != 0 || first.Y != 100000 {
+			t.Errorf("Samples(%d)[0] = %+v, want {X:0 Y:100000}", n, first)
+		}
+
+		if last.X != 1 || last.Y != 1000 {
+			t.Errorf("Samples(%d)[%d] = %+v, want {X:1 Y:1000}", n, n-1, last)
+		}
+
+		for idx := 1; idx < n; idx++ {
+			if samples[idx].X <= samples[idx-1].X || samples[idx].Y > samples[idx-1].Y {
+				t.Fatalf("Samples(%d)[%d] = %+v after %+v, want X increasing and Y non-increasing",
+					n, idx, samples[idx], samples[idx-1])
+			}
+		}
+	}
+
+	for _, n := range []int{-1, 0, 1} {
+		if _, err := system.Samples(n); !errors.Is(err, SampleCountOutOfRangeErr) {
+			t.Errorf("Samples(%d) = %v, want SampleCountOutOfRangeErr", n, err)
+		}
+	}
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
@@ -0,0 +1,92 @@
+package bezierscore
+
+import "math"
+
+// System32 is the float32 counterpart of System, for callers that want to halve the memory used by scores. Its curve
+// math is performed in float32, except for raising alpha to the power of exp, which the math package only offers in
+// float64. See alpha.
+//
+// System32 only supports the parameters accepted by New32, which are validated exactly like New's.
+type System32 struct {
+	participantCount   uint
+	upperBound         float32
+	lowerBound         float32
+	controlCoefficient float32
+	exponent           float32
+	controlPoint       float32
+}
+
+func New32(participantCount uint, scoreMin, scoreMax, coeff, exp float32) (*System32, error) {
+	if _, err := New(participantCount, float64(scoreMin), float64(scoreMax), float64(coeff), float64(exp)); err != nil {
+		return nil, err
+	}
+
+	s := &System32{
+		participantCount:   participantCount,
+		upperBound:         scoreMax,
+		lowerBound:         scoreMin,
+		controlCoefficient: coeff,
+		exponent:           exp,
+	}
+
+	s.controlPoint = s.control()
+	return s, nil
+}
+
+// alpha returns the warped interpolation parameter for position. The linear alpha is computed in float32, then widened
+// to float64 for math.Pow and rounded back to float32, so the warp is at least as accurate as a float32 power function
+// would be.
+func (s *System32) alpha(position uint) float32 {
+	numerator := float32(position - 1)
+	denominator := float32(s.participantCount - 1)
+	return float32(math.Pow(float64(numerator/denominator), float64(s.exponent)))
+}
+
+func (s *System32) control() float32 {
+	middle := (s.lowerBound / 2.0) + (s.upperBound / 2.0)
+	return ((1 - s.controlCoefficient) * middle) + (s.controlCoefficient * s.upperBound)
+}
+
+// Score returns the computed Bezier score for any given position in a leaderboard. See System.Score.
+func (s *System32) Score(position uint) (score float32, ok bool) {
+	if position == 0 || position > s.participantCount {
+		return 0, false
+	}
+
+	// clamp away rounding past the score range, as System's curve does.
+	score = bezier(s.upperBound, s.lowerBound, s.controlPoint, s.alpha(position))
+	return min(max(score, s.lowerBound), s.upperBound), true
+}
+
+// ScoreAll computes the Bezier score for every index in buf. See System.ScoreAll.
+//
+// len(buf) must equal participantCount.
+func (s *System32) ScoreAll(buf []float32) (ok bool) {
+	if uint(len(buf)) != s.participantCount {
+		return false
+	}
+
+	for idx := uint(0); idx < uint(len(buf)); idx++ {
+		buf[idx], _ = s.Score(idx + 1)
+	}
+
+	return true
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
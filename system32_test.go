@@ -0,0 +1,74 @@
+package bezierscore
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSystem32MatchesSystem(t *testing.T) {
+	for _, exp := range []float32{1, 1.33, 3} {
+		system32, err := New32(500, 1000, 100000, 0.5, exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		system := newSystem(t, 500, 1000, 100000, 0.5, float64(exp))
+		for position := uint(1); position <= 500; position++ {
+			got, _ := system32.Score(position)
+			want, _ := system.Score(position)
+
+			// float32 carries 24 bits of mantissa, and the terms of the curve are on the order of scoreMax, so allow an
+			// error of a few ulps of scoreMax.
+			if math.Abs(float64(got)-want) > 1e-6*100000 {
+				t.Fatalf("exp %g: System32.Score(%d) = %g, want within 0.1 of %g", exp, position, got, want)
+			}
+		}
+	}
+}
+
+func TestSystem32ScoreAll(t *testing.T) {
+	system, err := New32(500, 1000, 100000, 0.5, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]float32, 500)
+	if !system.ScoreAll(buf) {
+		t.Fatal("ScoreAll = false, want true")
+	}
+
+	for idx, score := range buf {
+		if want, _ := system.Score(uint(idx) + 1); score != want {
+			t.Fatalf("buf[%d] = %g, want %g", idx, score, want)
+		}
+	}
+
+	if system.ScoreAll(make([]float32, 499)) {
+		t.Error("ScoreAll of a short buffer = true, want false")
+	}
+}
+
+func TestNew32Validates(t *testing.T) {
+	if _, err := New32(500, 1000, 100000, 1.5, 1); !errors.Is(err, CoefficientOutOfRangeErr) {
+		t.Errorf("New32 = %v, want CoefficientOutOfRangeErr", err)
+	}
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
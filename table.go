@@ -0,0 +1,108 @@
+package bezierscore
+
+import (
+	"math"
+	"sort"
+)
+
+// Table holds a precomputed score for every position of a System, for callers that look up the same scores many
+// times. A Table is immutable and safe for concurrent use.
+type Table struct {
+	scores []float64
+
+	// cumulative holds the running total of scores, so that cumulative[i] is the sum of scores[0] through scores[i].
+	cumulative []float64
+
+	// reversed is copied from the System, so that Rank knows which way the scores are ordered.
+	reversed bool
+
+	// overridden is set when the System has overrides, so that Rank knows the scores may not be ordered at all.
+	overridden bool
+}
+
+// Precompute computes the Bezier score for every position once, returning a Table that answers further lookups with
+// a slice index rather than by evaluating the curve.
+func (s *System) Precompute() *Table {
+	scores := s.ScoreSlice()
+	cumulative := make([]float64, len(scores))
+	sum := 0.0
+	for idx, score := range scores {
+		sum += score
+		cumulative[idx] = sum
+	}
+
+	return &Table{scores: scores, cumulative: cumulative, reversed: s.reversed, overridden: len(s.overrides) > 0}
+}
+
+// Lookup returns the precomputed score for position, which is identical to the score returned by the System's Score.
+//
+// ok is false if position is 0 or greater than the participantCount of the System the Table was computed from.
+func (t *Table) Lookup(position uint) (score float64, ok bool) {
+	if position == 0 || position > uint(len(t.scores)) {
+		return 0, false
+	}
+
+	return t.scores[position-1], true
+}
+
+// Rank returns the position whose precomputed score is closest to score, which is identical to the position returned
+// by the System's Rank. It binary searches the precomputed scores, so it never evaluates the curve, unless the System
+// had overrides, in which case it scans them just as the System's Rank does.
+//
+// score must be between the scores of first and last place inclusive. When score falls exactly between two positions,
+// the better (lower) position is returned.
+func (t *Table) Rank(score float64) (position uint, ok bool) {
+	if t.overridden {
+		return rankScan(uint(len(t.scores)), func(position uint) float64 { return t.scores[position-1] }, score)
+	}
+
+	first, last := t.scores[0], t.scores[len(t.scores)-1]
+	if !(score >= min(first, last) && score <= max(first, last)) {
+		return 0, false
+	}
+
+	// find the first position whose score has reached the given score, as System.Rank does.
+	idx := sort.Search(len(t.scores), func(i int) bool {
+		if t.reversed {
+			return t.scores[i] >= score
+		}
+
+		return t.scores[i] <= score
+	})
+
+	if idx > 0 && math.Abs(t.scores[idx-1]-score) <= math.Abs(t.scores[idx]-score) {
+		idx--
+	}
+
+	return uint(idx) + 1, true
+}
+
+// CumulativeScore returns the precomputed sum of the scores for positions 1 through position inclusive, which is
+// identical to the sum returned by the System's CumulativeScore.
+//
+// ok is false if position is 0 or greater than the participantCount of the System the Table was computed from.
+func (t *Table) CumulativeScore(position uint) (sum float64, ok bool) {
+	if position == 0 || position > uint(len(t.cumulative)) {
+		return 0, false
+	}
+
+	return t.cumulative[position-1], true
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
@@ -0,0 +1,136 @@
+package bezierscore
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTableLookup(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	table := system.Precompute()
+	for position := uint(1); position <= 500; position++ {
+		want, _ := system.Score(position)
+		if got, ok := table.Lookup(position); !ok || got != want {
+			t.Fatalf("Lookup(%d) = %g, %t, want %g", position, got, ok, want)
+		}
+	}
+
+	for _, position := range []uint{0, 501} {
+		if score, ok := table.Lookup(position); ok {
+			t.Errorf("Lookup(%d) = %g, true, want false", position, score)
+		}
+	}
+}
+
+func BenchmarkTableLookup(b *testing.B) {
+	system := newSystem(b, 500, 1000, 100000, 0.5, 1.33)
+	table := system.Precompute()
+
+	b.Run("score", func(b *testing.B) {
+		for b.Loop() {
+			for position := uint(1); position <= 500; position++ {
+				system.Score(position)
+			}
+		}
+	})
+
+	b.Run("lookup", func(b *testing.B) {
+		for b.Loop() {
+			for position := uint(1); position <= 500; position++ {
+				table.Lookup(position)
+			}
+		}
+	})
+}
+
+func TestCumulativeScore(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	table := system.Precompute()
+
+	sum := 0.0
+	for position := uint(1); position <= 500; position++ {
+		score, _ := system.Score(position)
+		sum += score
+
+		if got, ok := system.CumulativeScore(position); !ok || got != sum {
+			t.Fatalf("System.CumulativeScore(%d) = %g, %t, want %g", position, got, ok, sum)
+		}
+
+		if got, ok := table.CumulativeScore(position); !ok || got != sum {
+			t.Fatalf("Table.CumulativeScore(%d) = %g, %t, want %g", position, got, ok, sum)
+		}
+	}
+
+	if got, _ := system.CumulativeScore(500); got != system.TotalScore() {
+		t.Errorf("CumulativeScore(500) = %g, want TotalScore() = %g", got, system.TotalScore())
+	}
+
+	for _, position := range []uint{0, 501} {
+		if sum, ok := system.CumulativeScore(position); ok {
+			t.Errorf("System.CumulativeScore(%d) = %g, true, want false", position, sum)
+		}
+
+		if sum, ok := table.CumulativeScore(position); ok {
+			t.Errorf("Table.CumulativeScore(%d) = %g, true, want false", position, sum)
+		}
+	}
+}
+
+func TestTableRank(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	reversed, err := system.With(WithReversed(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, system := range []*System{system, reversed} {
+		table := system.Precompute()
+		for score := 1000.0; score <= 100000; score += 97 {
+			want, _ := system.Rank(score)
+			if got, ok := table.Rank(score); !ok || got != want {
+				t.Fatalf("reversed %t: Rank(%g) = %d, %t, want %d, true", system.reversed, score, got, ok, want)
+			}
+		}
+
+		for _, score := range []float64{999, 100001, math.NaN()} {
+			if position, ok := table.Rank(score); ok {
+				t.Errorf("reversed %t: Rank(%g) = %d, true, want false", system.reversed, score, position)
+			}
+		}
+	}
+}
+
+func BenchmarkTableRank(b *testing.B) {
+	system := newSystem(b, 500, 1000, 100000, 0.5, 1.33)
+	table := system.Precompute()
+
+	b.Run("system", func(b *testing.B) {
+		for b.Loop() {
+			system.Rank(42000)
+		}
+	})
+
+	b.Run("table", func(b *testing.B) {
+		for b.Loop() {
+			table.Rank(42000)
+		}
+	})
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
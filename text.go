@@ -0,0 +1,261 @@
+package bezierscore
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Parse reads a System from r in the key=value text format produced by Write, e.g.
+//
+//	participants=500 min=1000 max=100000 coeff=0.5 exp=1.33
+//
+// Pairs may appear in any order and be separated by any whitespace. participants, min, max and exp are required, as is
+// exactly one of coeff or control. coeff2, expmode, reversed, quantum, unbounded, roundcontrol, integerscores,
+// floorposition, floorscore, scorecap, decay and overrides are optional, and any other key is an error. coeff2 makes
+// the System cubic like NewCubic, control sets the control point like NewWithControl, and unbounded relaxes scoreMin
+// like NewUnbounded. overrides lists comma-separated position:score pairs, like WithOverride. The parameters are
+// validated with Validate.
+func Parse(r io.Reader) (*System, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseText(string(data))
+}
+
+func parseText(text string) (*System, error) {
+	var (
+		participantCount uint64
+		floorPosition    uint64
+		exponentMode     int64
+		overrides        map[uint]float64
+		floats           = map[string]float64{}
+		bools            = map[string]bool{}
+		seen             = map[string]bool{}
+	)
+
+	for _, pair := range strings.Fields(text) {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("bezierscore: expected key=value, got %q", pair)
+		}
+
+		if seen[key] {
+			return nil, fmt.Errorf("bezierscore: duplicate key %q", key)
+		}
+
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "participants":
+			participantCount, err = strconv.ParseUint(value, 10, 0)
+		case "floorposition":
+			floorPosition, err = strconv.ParseUint(value, 10, 0)
+		case "expmode":
+			exponentMode, err = strconv.ParseInt(value, 10, 0)
+		case "overrides":
+			overrides, err = parseOverrides(value)
+		case "min", "max", "coeff", "coeff2", "control", "exp", "quantum", "floorscore", "scorecap", "decay":
+			floats[key], err = strconv.ParseFloat(value, 64)
+		case "reversed", "unbounded", "roundcontrol", "integerscores":
+			bools[key], err = strconv.ParseBool(value)
+		default:
+			return nil, fmt.Errorf("bezierscore: unknown key %q", key)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("bezierscore: invalid value for %q: %w", key, err)
+		}
+	}
+
+	for _, key := range []string{"participants", "min", "max", "exp"} {
+		if !seen[key] {
+			return nil, fmt.Errorf("bezierscore: missing key %q", key)
+		}
+	}
+
+	if seen["coeff"] == seen["control"] {
+		return nil, fmt.Errorf("bezierscore: exactly one of %q and %q is required", "coeff", "control")
+	}
+
+	system := &System{
+		participantCount:    uint(participantCount),
+		upperBound:          floats["max"],
+		lowerBound:          floats["min"],
+		controlCoefficient:  floats["coeff"],
+		exponent:            floats["exp"],
+		exponentMode:        ExponentMode(exponentMode),
+		reversed:            bools["reversed"],
+		quantum:             floats["quantum"],
+		unbounded:           bools["unbounded"],
+		roundControl:        bools["roundcontrol"],
+		integerScores:       bools["integerscores"],
+		floorPosition:       uint(floorPosition),
+		floorScore:          floats["floorscore"],
+		scoreCap:            floats["scorecap"],
+		decayRate:           floats["decay"],
+		overrides:           overrides,
+		cubic:               seen["coeff2"],
+		controlCoefficient2: floats["coeff2"],
+		explicitControl:     seen["control"],
+		controlPoint:        floats["control"],
+	}
+
+	if err := system.Validate(); err != nil {
+		return nil, fmt.Errorf("bezierscore: invalid System: %w", err)
+	}
+
+	system.prepare()
+	return system, nil
+}
+
+// parseOverrides parses the value of the overrides key: comma-separated position:score pairs, e.g. 1:50000,2:30000.
+func parseOverrides(value string) (map[uint]float64, error) {
+	overrides := map[uint]float64{}
+	for pair := range strings.SplitSeq(value, ",") {
+		positionText, scoreText, found := strings.Cut(pair, ":")
+		if !found {
+			return nil, fmt.Errorf("expected position:score, got %q", pair)
+		}
+
+		position, err := strconv.ParseUint(positionText, 10, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, duplicate := overrides[uint(position)]; duplicate {
+			return nil, fmt.Errorf("duplicate position %d", position)
+		}
+
+		overrides[uint(position)], err = strconv.ParseFloat(scoreText, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return overrides, nil
+}
+
+// Write writes the System's parameters to w in the key=value text format read by Parse, followed by a newline. Systems
+// constructed with NewWithEasing or NewPiecewise cannot be written.
+func (s *System) Write(w io.Writer) error {
+	if err := s.encodable(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, s.text()+"\n")
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the same key=value format as Write without the trailing
+// newline. Systems constructed with NewWithEasing or NewPiecewise cannot be encoded.
+func (s *System) MarshalText() ([]byte, error) {
+	if err := s.encodable(); err != nil {
+		return nil, err
+	}
+
+	return []byte(s.text()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the key=value format read by Parse. The decoded
+// parameters are validated with Validate, and any violation is returned wrapping the corresponding error.
+func (s *System) UnmarshalText(text []byte) error {
+	system, err := parseText(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = *system
+	return nil
+}
+
+func (s *System) text() string {
+	var builder strings.Builder
+	builder.WriteString("participants=" + strconv.FormatUint(uint64(s.participantCount), 10))
+	builder.WriteString(" min=" + strconv.FormatFloat(s.lowerBound, 'g', -1, 64))
+	builder.WriteString(" max=" + strconv.FormatFloat(s.upperBound, 'g', -1, 64))
+	if s.explicitControl {
+		builder.WriteString(" control=" + strconv.FormatFloat(s.controlPoint, 'g', -1, 64))
+	} else {
+		builder.WriteString(" coeff=" + strconv.FormatFloat(s.controlCoefficient, 'g', -1, 64))
+	}
+
+	if s.cubic {
+		builder.WriteString(" coeff2=" + strconv.FormatFloat(s.controlCoefficient2, 'g', -1, 64))
+	}
+
+	builder.WriteString(" exp=" + strconv.FormatFloat(s.exponent, 'g', -1, 64))
+	if s.exponentMode != ExpBackLoad {
+		builder.WriteString(" expmode=" + strconv.Itoa(int(s.exponentMode)))
+	}
+
+	if s.reversed {
+		builder.WriteString(" reversed=true")
+	}
+
+	if s.quantum != 0 {
+		builder.WriteString(" quantum=" + strconv.FormatFloat(s.quantum, 'g', -1, 64))
+	}
+
+	if s.unbounded {
+		builder.WriteString(" unbounded=true")
+	}
+
+	if s.roundControl {
+		builder.WriteString(" roundcontrol=true")
+	}
+
+	if s.integerScores {
+		builder.WriteString(" integerscores=true")
+	}
+
+	if s.floorPosition > 0 {
+		builder.WriteString(" floorposition=" + strconv.FormatUint(uint64(s.floorPosition), 10))
+		builder.WriteString(" floorscore=" + strconv.FormatFloat(s.floorScore, 'g', -1, 64))
+	}
+
+	if s.scoreCap > 0 {
+		builder.WriteString(" scorecap=" + strconv.FormatFloat(s.scoreCap, 'g', -1, 64))
+	}
+
+	if s.decayRate > 0 {
+		builder.WriteString(" decay=" + strconv.FormatFloat(s.decayRate, 'g', -1, 64))
+	}
+
+	for idx, position := range slices.Sorted(maps.Keys(s.overrides)) {
+		if idx == 0 {
+			builder.WriteString(" overrides=")
+		} else {
+			builder.WriteString(",")
+		}
+
+		builder.WriteString(strconv.FormatUint(uint64(position), 10) + ":")
+		builder.WriteString(strconv.FormatFloat(s.overrides[position], 'g', -1, 64))
+	}
+
+	return builder.String()
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/
@@ -0,0 +1,134 @@
+package bezierscore
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteParseRoundTrip(t *testing.T) {
+	system := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+
+	var buf bytes.Buffer
+	if err := system.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "participants=500 min=1000 max=100000 coeff=0.5 exp=1.33\n"; buf.String() != want {
+		t.Errorf("Write = %q, want %q", buf.String(), want)
+	}
+
+	parsed, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !parsed.Equal(system) {
+		t.Errorf("Parse = %v, want %v", parsed, system)
+	}
+}
+
+func TestParseToleratesWhitespaceAndOrder(t *testing.T) {
+	parsed, err := Parse(strings.NewReader("\texp=1.33\n  coeff=0.5 max=100000\r\nmin=1000   participants=500\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := newSystem(t, 500, 1000, 100000, 0.5, 1.33); !parsed.Equal(want) {
+		t.Errorf("Parse = %v, want %v", parsed, want)
+	}
+}
+
+func TestParseRejectsMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"unknown key", "participants=500 min=1000 max=100000 coeff=0.5 exp=1.33 bonus=1"},
+		{"missing key", "participants=500 min=1000 max=100000 coeff=0.5"},
+		{"missing equals", "participants=500 min=1000 max 100000 coeff=0.5 exp=1.33"},
+		{"duplicate key", "participants=500 min=1000 max=100000 coeff=0.5 exp=1.33 exp=2"},
+		{"bad number", "participants=500 min=1000 max=lots coeff=0.5 exp=1.33"},
+		{"invalid parameter", "participants=500 min=1000 max=100000 coeff=1.5 exp=1.33"},
+		{"empty", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if system, err := Parse(strings.NewReader(test.text)); err == nil {
+				t.Errorf("Parse(%q) = %v, want an error", test.text, system)
+			}
+		})
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	base := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	withOptions, err := base.With(WithReversed(true), WithQuantum(10), WithFloorBelow(400, 2000), WithScoreCap(90000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withOptions, err = withOptions.WithOverride(1, 95000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cubic, err := NewCubic(500, 1000, 100000, 0.25, 0.75, 1.33)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, system := range []*System{base, withOptions, cubic} {
+		text, err := system.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded System
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q): %v", text, err)
+		}
+
+		if !decoded.Equal(system) {
+			t.Errorf("UnmarshalText(%q) = %v, want %v", text, &decoded, system)
+		}
+	}
+}
+
+func TestUnmarshalTextRejectsMalformed(t *testing.T) {
+	original := newSystem(t, 500, 1000, 100000, 0.5, 1.33)
+	decoded := *original
+
+	if err := decoded.UnmarshalText([]byte("participants=500 min=1000")); err == nil {
+		t.Error("UnmarshalText with missing keys succeeded, want an error")
+	}
+
+	err := decoded.UnmarshalText([]byte("participants=500 min=1000 max=100000 coeff=1.5 exp=1.33"))
+	if !errors.Is(err, CoefficientOutOfRangeErr) {
+		t.Errorf("UnmarshalText with an invalid coeff = %v, want CoefficientOutOfRangeErr", err)
+	}
+
+	if !decoded.Equal(original) {
+		t.Errorf("failed UnmarshalText modified the System to %v", &decoded)
+	}
+}
+
+/*
+
+Copyright 2026 dresswithpockets
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+*/